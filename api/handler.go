@@ -1,14 +1,21 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/mockingio/mockingio/engine/har"
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/scenario"
+	"github.com/mockingio/mockingio/engine/verification"
 )
 
 func (s *Server) GetMocksHandler(w http.ResponseWriter, r *http.Request) {
@@ -105,3 +112,164 @@ func (s *Server) StartMockServerHandler(w http.ResponseWriter, r *http.Request)
 
 	response(w, http.StatusOK, resp)
 }
+
+func (s *Server) GetRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	mockID := mux.Vars(r)["mock_id"]
+
+	filter, err := toJournalFilter(r)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	requests, err := s.db.GetRequests(r.Context(), mockID, filter)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response(w, http.StatusOK, requests)
+}
+
+// GetRequestsHARHandler exports the requests and the responses served as an HTTP archive
+func (s *Server) GetRequestsHARHandler(w http.ResponseWriter, r *http.Request) {
+	mockID := mux.Vars(r)["mock_id"]
+
+	filter, err := toJournalFilter(r)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	requests, err := s.db.GetRequests(r.Context(), mockID, filter)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response(w, http.StatusOK, har.FromJournal(requests, s.version))
+}
+
+func (s *Server) ClearRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	mockID := mux.Vars(r)["mock_id"]
+
+	if err := s.db.ClearRequests(r.Context(), mockID); err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response(w, http.StatusOK, nil)
+}
+
+func (s *Server) VerifyRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	mockID := mux.Vars(r)["mock_id"]
+
+	var v verification.Verification
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := v.Validate(); err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := verification.Verify(r.Context(), s.db, mockID, v)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response(w, http.StatusOK, result)
+}
+
+func (s *Server) GetScenariosHandler(w http.ResponseWriter, r *http.Request) {
+	mockID := mux.Vars(r)["mock_id"]
+
+	mok, err := s.db.GetMock(r.Context(), mockID)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if mok == nil {
+		responseError(w, http.StatusNotFound, errors.New("mock not found"))
+		return
+	}
+
+	scenarios, err := scenario.List(r.Context(), s.db, mok)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response(w, http.StatusOK, scenarios)
+}
+
+func (s *Server) SetScenarioStateHandler(w http.ResponseWriter, r *http.Request) {
+	mockID := mux.Vars(r)["mock_id"]
+	name := mux.Vars(r)["scenario"]
+
+	var data struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil || data.State == "" {
+		responseError(w, http.StatusBadRequest, errors.New("state is required"))
+		return
+	}
+
+	mok, err := s.db.GetMock(r.Context(), mockID)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if mok == nil || !lo.Contains(scenario.Names(mok), name) {
+		responseError(w, http.StatusNotFound, errors.New("scenario not found"))
+		return
+	}
+
+	sessionID, err := s.db.GetActiveSession(r.Context(), mockID)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := scenario.SetState(r.Context(), s.db, mockID, sessionID, name, data.State); err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response(w, http.StatusOK, scenario.Scenario{Name: name, State: data.State})
+}
+
+// toJournalFilter builds the requests filter from the query string
+func toJournalFilter(r *http.Request) (journal.Filter, error) {
+	query := r.URL.Query()
+	filter := journal.Filter{
+		SessionID:  query.Get("session_id"),
+		Method:     query.Get("method"),
+		Path:       query.Get("path"),
+		RouteID:    query.Get("route_id"),
+		ResponseID: query.Get("response_id"),
+	}
+
+	if value := query.Get("matched"); value != "" {
+		matched, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, errors.New("invalid matched value")
+		}
+		filter.Matched = &matched
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return filter, errors.New("invalid limit value")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
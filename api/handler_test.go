@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -16,6 +17,8 @@ import (
 	"github.com/mockingio/mockingio/api/fixtures"
 	"github.com/mockingio/mockingio/engine/database"
 	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/har"
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/mock"
 	"github.com/mockingio/mockingio/engine/server"
 )
@@ -262,6 +265,212 @@ func TestServer_StartStopMockServerHandler(t *testing.T) {
 	})
 }
 
+func TestServer_GetRequestsHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db := newDB(fixtures.Mock1())
+		_ = db.AddRequest(context.Background(), &journal.Entry{ID: "request1", MockID: "mock1", Method: "GET", Path: "/hello"})
+		_ = db.AddRequest(context.Background(), &journal.Entry{ID: "request2", MockID: "mock1", Method: "POST", Path: "/hello"})
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(db, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests?method=POST", nil)
+		req = mux.SetURLVars(req, map[string]string{"mock_id": "mock1"})
+
+		apiServer.GetRequestsHandler(writer, req)
+
+		var entries []*journal.Entry
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &entries))
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "request2", entries[0].ID)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(newDB(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests?limit=abc", nil)
+		apiServer.GetRequestsHandler(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+		writer = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests?matched=abc", nil)
+		apiServer.GetRequestsHandler(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(&mockDB{}, nil)
+
+		apiServer.GetRequestsHandler(writer, httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests", nil))
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	})
+}
+
+func TestServer_GetRequestsHARHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db := newDB(fixtures.Mock1())
+		_ = db.AddRequest(context.Background(), &journal.Entry{ID: "request1", MockID: "mock1", Method: "GET", URL: "/hello", Host: "localhost", Status: 200, ResponseBody: "Hello"})
+		_ = db.AddRequest(context.Background(), &journal.Entry{ID: "request2", MockID: "mock1", Method: "POST", URL: "/hello", Host: "localhost", Status: 201})
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(db, nil).WithVersion("v1.0.0")
+
+		req := httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests/har?method=GET", nil)
+		req = mux.SetURLVars(req, map[string]string{"mock_id": "mock1"})
+
+		apiServer.GetRequestsHARHandler(writer, req)
+
+		var archive har.HAR
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &archive))
+		assert.Equal(t, "v1.0.0", archive.Log.Creator.Version)
+		assert.Len(t, archive.Log.Entries, 1)
+		assert.Equal(t, "http://localhost/hello", archive.Log.Entries[0].Request.URL)
+		assert.Equal(t, "Hello", archive.Log.Entries[0].Response.Content.Text)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(newDB(), nil)
+
+		apiServer.GetRequestsHARHandler(writer, httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests/har?limit=abc", nil))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(&mockDB{}, nil)
+
+		apiServer.GetRequestsHARHandler(writer, httptest.NewRequest(http.MethodGet, "/mocks/mock1/requests/har", nil))
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	})
+}
+
+func TestServer_ClearRequestsHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db := newDB(fixtures.Mock1())
+		_ = db.AddRequest(context.Background(), &journal.Entry{ID: "request1", MockID: "mock1"})
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(db, nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/mocks/mock1/requests", nil)
+		req = mux.SetURLVars(req, map[string]string{"mock_id": "mock1"})
+
+		apiServer.ClearRequestsHandler(writer, req)
+
+		entries, _ := db.GetRequests(context.Background(), "mock1", journal.Filter{})
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(&mockDB{}, nil)
+
+		apiServer.ClearRequestsHandler(writer, httptest.NewRequest(http.MethodDelete, "/mocks/mock1/requests", nil))
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	})
+}
+
+func TestServer_VerifyRequestsHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db := newDB(fixtures.Mock1())
+		_ = db.AddRequest(context.Background(), &journal.Entry{MockID: "mock1", Method: "GET", URL: "/hello", Path: "/hello"})
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(db, nil)
+
+		req := httptest.NewRequest(
+			http.MethodPost,
+			"/mocks/mock1/requests/verify",
+			bytes.NewBufferString(`{"method": "GET", "path": "/hello", "mode": "exactly", "count": 1}`),
+		)
+		req = mux.SetURLVars(req, map[string]string{"mock_id": "mock1"})
+
+		apiServer.VerifyRequestsHandler(writer, req)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.JSONEq(t, `{"passed": true, "count": 1, "expected": "exactly 1 time"}`, writer.Body.String())
+	})
+
+	t.Run("invalid verification", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(newDB(), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/mocks/mock1/requests/verify", bytes.NewBufferString(`{"mode": "exactly"}`))
+		apiServer.VerifyRequestsHandler(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+		writer = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodPost, "/mocks/mock1/requests/verify", bytes.NewBufferString(`{`))
+		apiServer.VerifyRequestsHandler(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(&mockDB{}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/mocks/mock1/requests/verify", bytes.NewBufferString(`{"path": "/", "mode": "never"}`))
+		apiServer.VerifyRequestsHandler(writer, req)
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	})
+}
+
+func TestServer_ScenariosHandler(t *testing.T) {
+	scenarioMock := func() *mock.Mock {
+		mok := fixtures.Mock1()
+		mok.Routes[0].Responses[0].Scenario = "checkout"
+		return mok
+	}
+
+	t.Run("get and set scenario state", func(t *testing.T) {
+		db := newDB(scenarioMock())
+		_ = db.SetActiveSession(context.Background(), "mock1", "session1")
+		apiServer := NewServer(db, nil)
+		vars := map[string]string{"mock_id": "mock1", "scenario": "checkout"}
+
+		writer := httptest.NewRecorder()
+		apiServer.GetScenariosHandler(writer, mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), vars))
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.JSONEq(t, `[{"name": "checkout", "state": "started"}]`, writer.Body.String())
+
+		writer = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{"state": "paid"}`))
+		apiServer.SetScenarioStateHandler(writer, mux.SetURLVars(req, vars))
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		writer = httptest.NewRecorder()
+		apiServer.GetScenariosHandler(writer, mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), vars))
+		assert.JSONEq(t, `[{"name": "checkout", "state": "paid"}]`, writer.Body.String())
+	})
+
+	t.Run("mock not found", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(newDB(), nil)
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"mock_id": "mock1"})
+		apiServer.GetScenariosHandler(writer, req)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	t.Run("scenario not found", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(newDB(scenarioMock()), nil)
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{"state": "paid"}`))
+		req = mux.SetURLVars(req, map[string]string{"mock_id": "mock1", "scenario": "login"})
+		apiServer.SetScenarioStateHandler(writer, req)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	t.Run("missing state", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		apiServer := NewServer(newDB(scenarioMock()), nil)
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{}`))
+		apiServer.SetScenarioStateHandler(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
 func newDB(mocks ...*mock.Mock) database.Database {
 	db := memory.New()
 	for _, m := range mocks {
@@ -286,6 +495,14 @@ func (m *mockDB) PatchRoute(_ context.Context, _ string, _ string, _ string) err
 	return errors.New("something is not right")
 }
 
+func (m *mockDB) GetRequests(_ context.Context, _ string, _ journal.Filter) ([]*journal.Entry, error) {
+	return nil, errors.New("something is not right")
+}
+
+func (m *mockDB) ClearRequests(_ context.Context, _ string) error {
+	return errors.New("something is not right")
+}
+
 func (m *mockDB) PatchResponse(_ context.Context, _, _, _, _ string) error {
 	return errors.New("something is not right")
 }
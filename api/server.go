@@ -19,6 +19,8 @@ import (
 type Server struct {
 	db         database.CRUD
 	mockServer mockServer
+	// version is the mockingio version, written in the HTTP archives
+	version string
 }
 
 func NewServer(db database.CRUD, mockServer mockServer) *Server {
@@ -28,6 +30,12 @@ func NewServer(db database.CRUD, mockServer mockServer) *Server {
 	}
 }
 
+// WithVersion sets the mockingio version of the server
+func (s *Server) WithVersion(version string) *Server {
+	s.version = version
+	return s
+}
+
 func (s *Server) Start(_ context.Context, port string) (string, func(), error) {
 	r := mux.NewRouter()
 
@@ -41,6 +49,16 @@ func (s *Server) Start(_ context.Context, port string) (string, func(), error) {
 	r.Path("/mocks/{mock_id}/routes/{route_id}").HandlerFunc(s.PatchRouteHandler).Methods(http.MethodPatch)
 	r.Path("/mocks/{mock_id}/routes/{route_id}/responses/{response_id}").HandlerFunc(s.PatchResponseHandler).Methods(http.MethodPatch)
 
+	// requests journal
+	r.Path("/mocks/{mock_id}/requests").HandlerFunc(s.GetRequestsHandler).Methods(http.MethodGet)
+	r.Path("/mocks/{mock_id}/requests").HandlerFunc(s.ClearRequestsHandler).Methods(http.MethodDelete)
+	r.Path("/mocks/{mock_id}/requests/har").HandlerFunc(s.GetRequestsHARHandler).Methods(http.MethodGet)
+	r.Path("/mocks/{mock_id}/requests/verify").HandlerFunc(s.VerifyRequestsHandler).Methods(http.MethodPost)
+
+	// scenarios
+	r.Path("/mocks/{mock_id}/scenarios").HandlerFunc(s.GetScenariosHandler).Methods(http.MethodGet)
+	r.Path("/mocks/{mock_id}/scenarios/{scenario}").HandlerFunc(s.SetScenarioStateHandler).Methods(http.MethodPut)
+
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen to tcp port: %s", port)
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mockingio/mockingio/cmd/version"
+	"github.com/mockingio/mockingio/engine/har"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+var (
+	exportAdminURL string
+	exportMockID   string
+	exportOutput   string
+)
+
+// exportCmd represents the export command, the formats are sub commands
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the requests received by a running mock server",
+}
+
+var exportHARCmd = &cobra.Command{
+	Use:   "har",
+	Short: "Export the requests and the responses served as an HTTP archive",
+	Long: `
+mockingio export har
+mockingio export har --mock mock-id --output requests.har --admin-url http://localhost:2601
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mockIDs := []string{exportMockID}
+		if exportMockID == "" {
+			var mocks []*mock.Mock
+			if err := getAdmin("/mocks", &mocks); err != nil {
+				reportError(err)
+			}
+
+			mockIDs = nil
+			for _, mok := range mocks {
+				mockIDs = append(mockIDs, mok.ID)
+			}
+		}
+
+		archive := har.FromJournal(nil, version.Version)
+		for _, mockID := range mockIDs {
+			var mockArchive har.HAR
+			if err := getAdmin(fmt.Sprintf("/mocks/%s/requests/har", mockID), &mockArchive); err != nil {
+				reportError(err)
+			}
+			archive.Log.Entries = append(archive.Log.Entries, mockArchive.Log.Entries...)
+		}
+
+		sort.SliceStable(archive.Log.Entries, func(i, j int) bool {
+			return archive.Log.Entries[i].StartedDateTime.Before(archive.Log.Entries[j].StartedDateTime)
+		})
+
+		data, err := json.MarshalIndent(archive, "", "  ")
+		if err != nil {
+			reportError(err)
+		}
+
+		if err := ioutil.WriteFile(exportOutput, data, 0644); err != nil {
+			reportError(errors.Wrap(err, "write file"))
+		}
+
+		fmt.Printf("Exported %d requests to %s\n", len(archive.Log.Entries), exportOutput)
+	},
+}
+
+// getAdmin calls the admin API of a running mock server
+func getAdmin(path string, data any) error {
+	res, err := http.Get(strings.TrimRight(exportAdminURL, "/") + path)
+	if err != nil {
+		return errors.Wrap(err, "call admin API")
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("admin API responded with status %d", res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(data); err != nil {
+		return errors.Wrap(err, "decode admin API response")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.PersistentFlags().StringVar(&exportAdminURL, "admin-url", fmt.Sprintf("http://localhost:%d", adminPort), "URL of the admin API of the running mock server")
+	exportCmd.PersistentFlags().StringVarP(&exportMockID, "mock", "m", "", "ID of the mock to export, all mocks if empty")
+	exportCmd.PersistentFlags().StringVarP(&exportOutput, "output", "o", "requests.har", "file to write the export to")
+
+	exportCmd.AddCommand(exportHARCmd)
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mockingio/mockingio/engine/har"
+	"github.com/mockingio/mockingio/engine/importer"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+var (
+	importOutput string
+	importPort   string
+)
+
+// importCmd represents the import command, the formats are sub commands
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a mock from another format",
+}
+
+var importOpenAPICmd = &cobra.Command{
+	Use:   "openapi [spec file]",
+	Short: "Import a mock from an OpenAPI 3 spec",
+	Long: `
+mockingio import openapi spec.yaml
+mockingio import openapi spec.json --output mock.yml --port 8080
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		imported, err := importer.FromOpenAPIFile(args[0])
+		if err != nil {
+			reportError(err)
+		}
+
+		if err := writeImport(imported, importOutput); err != nil {
+			reportError(err)
+		}
+
+		fmt.Printf("Imported %d routes to %s\n", len(imported.Routes), importOutput)
+	},
+}
+
+var importHARCmd = &cobra.Command{
+	Use:   "har [HAR file]",
+	Short: "Import a mock from an HTTP archive",
+	Long: `
+mockingio import har session.har
+mockingio import har session.har --output mock.yml --port 8080
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		imported, err := har.ImportFile(args[0])
+		if err != nil {
+			reportError(err)
+		}
+
+		if err := writeImport(imported, importOutput); err != nil {
+			reportError(err)
+		}
+
+		fmt.Printf("Imported %d routes to %s\n", len(imported.Routes), importOutput)
+	},
+}
+
+var importPostmanCmd = &cobra.Command{
+	Use:   "postman [collection file]",
+	Short: "Import a mock from the saved examples of a Postman collection",
+	Long: `
+mockingio import postman collection.json
+mockingio import postman collection.json --output mock.yml --port 8080
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		imported, report, err := importer.FromPostmanFile(args[0])
+		if err != nil {
+			reportError(err)
+		}
+
+		if err := writeImport(imported, importOutput); err != nil {
+			reportError(err)
+		}
+
+		printImportReport(imported, report)
+	},
+}
+
+var importWireMockCmd = &cobra.Command{
+	Use:   "wiremock [root directory or mapping files]",
+	Short: "Import a mock from WireMock mappings",
+	Long: `
+mockingio import wiremock ./wiremock
+mockingio import wiremock mappings/users.json mappings/orders.json --output mock.yml
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		imported, report, err := importer.FromWireMockFiles(args...)
+		if err != nil {
+			reportError(err)
+		}
+
+		if err := writeImport(imported, importOutput); err != nil {
+			reportError(err)
+		}
+
+		printImportReport(imported, report)
+	},
+}
+
+// printImportReport prints the imported routes, and what could not be translated
+func printImportReport(mok *mock.Mock, report *importer.Report) {
+	fmt.Printf("Imported %d routes to %s\n", len(mok.Routes), importOutput)
+
+	if len(report.Items) > 0 {
+		fmt.Printf("\n%d items could not be translated:\n%s", len(report.Items), report)
+	}
+}
+
+// writeImport writes the imported mock to the output file, in the format of the file extension
+func writeImport(mok *mock.Mock, filename string) error {
+	mok.Port = importPort
+
+	text, err := mok.Marshal(mock.DetectFormat(filename, nil))
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filename, text, 0644); err != nil {
+		return errors.Wrap(err, "write file")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.PersistentFlags().StringVarP(&importOutput, "output", "o", "mock.yml", "file to write the imported mock to")
+	importCmd.PersistentFlags().StringVarP(&importPort, "port", "p", "", "port of the imported mock, random if empty")
+
+	importCmd.AddCommand(importOpenAPICmd)
+	importCmd.AddCommand(importHARCmd)
+	importCmd.AddCommand(importPostmanCmd)
+	importCmd.AddCommand(importWireMockCmd)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/server"
+)
+
+var (
+	recordTarget             string
+	recordOutput             string
+	recordPort               string
+	recordInsecureSkipVerify bool
+	recordRedactHeaders      []string
+	recordRedactBodyFields   []string
+	recordMatchHeaders       []string
+	recordMatchQueryStrings  bool
+	recordMatchBody          bool
+)
+
+// recordCmd represents the record command
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Proxy requests to a real upstream and record the responses as routes",
+	Long: `
+mockingio record --target https://api.example.com --output mock.yml
+mockingio record --target https://api.example.com --output mock.yml --port 8080 --redact-header Authorization
+mockingio record --target https://api.example.com --output mock.yml --match-query --match-header X-Tenant
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		db := memory.New()
+		mockServer := server.New(db)
+
+		recordMock := &mock.Mock{
+			ID:   uuid.NewString(),
+			Name: fmt.Sprintf("Recorded from %s", recordTarget),
+			Port: recordPort,
+			Proxy: &mock.Proxy{
+				Enabled:            true,
+				Host:               recordTarget,
+				InsecureSkipVerify: recordInsecureSkipVerify,
+				Record: &mock.Record{
+					Enabled:           true,
+					RedactHeaders:     recordRedactHeaders,
+					RedactBodyFields:  recordRedactBodyFields,
+					MatchHeaders:      recordMatchHeaders,
+					MatchQueryStrings: recordMatchQueryStrings,
+					MatchBody:         recordMatchBody,
+				},
+			},
+		}
+
+		if err := recordMock.Proxy.Validate(); err != nil {
+			reportError(err)
+		}
+
+		// save recorded routes to the output file
+		db.SubscribeMockChanges(func(mok mock.Mock) {
+			if err := writeRecording(mok, recordOutput); err != nil {
+				log.WithError(err).Errorf("failed to write recording to file %s", recordOutput)
+			}
+		})
+
+		if err := db.SetMock(ctx, recordMock); err != nil {
+			reportError(err)
+		}
+
+		if _, err := mockServer.NewMockServer(ctx, recordMock); err != nil {
+			fmt.Printf("Failed to start record server. Error: %v\n", err)
+			quit(mockServer)
+		}
+
+		data, _ := json.Marshal(map[string]any{
+			"urls":   mockServer.GetMockServerURLs(),
+			"output": recordOutput,
+		})
+		fmt.Println(string(data))
+
+		onStopSignal(mockServer.StopAllServers)
+	},
+}
+
+// writeRecording writes the recorded mock without the proxy, so it can be replayed with the start command
+func writeRecording(mok mock.Mock, filename string) error {
+	if len(mok.Routes) == 0 {
+		return nil
+	}
+
+	mok.Proxy = nil
+	text, err := mok.Marshal(mock.DetectFormat(filename, nil))
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filename, text, 0644); err != nil {
+		return errors.Wrap(err, "write file")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(recordCmd)
+	recordCmd.Flags().StringVarP(&recordTarget, "target", "t", "", "upstream host to proxy the requests to")
+	recordCmd.Flags().StringVarP(&recordOutput, "output", "o", "mock.yml", "file to write the recorded mock to")
+	recordCmd.Flags().StringVarP(&recordPort, "port", "p", "", "port of the record server, random if empty")
+	recordCmd.Flags().BoolVar(&recordInsecureSkipVerify, "insecure-skip-verify", false, "skip TLS verification of the upstream")
+	recordCmd.Flags().StringArrayVar(&recordRedactHeaders, "redact-header", []string{}, "header to redact")
+	recordCmd.Flags().StringArrayVar(&recordRedactBodyFields, "redact-body-field", []string{}, "JSON body field to redact")
+	recordCmd.Flags().StringArrayVar(&recordMatchHeaders, "match-header", []string{}, "request header to save as rule")
+	recordCmd.Flags().BoolVar(&recordMatchQueryStrings, "match-query", false, "save request query strings as rules")
+	recordCmd.Flags().BoolVar(&recordMatchBody, "match-body", false, "save request body as rule")
+	_ = recordCmd.MarkFlagRequired("target")
+}
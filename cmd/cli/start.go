@@ -15,18 +15,20 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 
 	"github.com/mockingio/mockingio/api"
+	"github.com/mockingio/mockingio/cmd/version"
 	"github.com/mockingio/mockingio/engine/database"
 	"github.com/mockingio/mockingio/engine/database/memory"
 	"github.com/mockingio/mockingio/engine/mock"
 	"github.com/mockingio/mockingio/engine/server"
+	"github.com/mockingio/mockingio/engine/watcher"
 )
 
 var filenames []string
 var adminPort = 2601
 var filePersist = false
+var watchFiles = false
 
 // startCmd represents the start command
 var startCmd = &cobra.Command{
@@ -36,6 +38,7 @@ var startCmd = &cobra.Command{
 mockingio start --filename mock.yml
 mockingio start --filename mock1.yml --filename mock2.yml
 mockingio start --filename mock.yml --output-json
+mockingio start --filename mock.yml --watch
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -53,7 +56,7 @@ mockingio start --filename mock.yml --output-json
 		}
 
 		// start admin server
-		adminURL, shutdownServer, err := api.NewServer(db, mockServer).Start(ctx, strconv.Itoa(adminPort))
+		adminURL, shutdownServer, err := api.NewServer(db, mockServer).WithVersion(version.Version).Start(ctx, strconv.Itoa(adminPort))
 		if err != nil {
 			log.WithError(err).Error("Failed to start api server")
 			shutdownServer()
@@ -69,16 +72,37 @@ mockingio start --filename mock.yml --output-json
 			})
 		}
 
-		printServersInfo(mockServer.GetMockServerURLs(), adminURL)
+		// reload mocks when their files change
+		if watchFiles {
+			fileWatcher, err := watcher.New(db, mockServer)
+			if err != nil {
+				reportError(err)
+			}
+			defer func() { _ = fileWatcher.Close() }()
+
+			for _, item := range mockFileMap {
+				if err := fileWatcher.Add(item.filename, item.mock); err != nil {
+					reportError(err)
+				}
+			}
+			fileWatcher.Start(ctx)
+		}
+
+		printServersInfo(mockServer.GetMockServerURLs(), mockServer.GetGRPCAddresses(), adminURL)
 		onStopSignal(mockServer.StopAllServers)
 	},
 }
 
-func printServersInfo(mockUrls []string, adminURL string) {
-	data, _ := json.Marshal(map[string]any{
+func printServersInfo(mockUrls []string, grpcAddresses []string, adminURL string) {
+	info := map[string]any{
 		"urls":      mockUrls,
 		"admin_url": adminURL,
-	})
+	}
+	if len(grpcAddresses) > 0 {
+		info["grpc_addresses"] = grpcAddresses
+	}
+
+	data, _ := json.Marshal(info)
 	fmt.Println(string(data))
 }
 
@@ -140,9 +164,9 @@ func onStopSignal(handler func()) {
 }
 
 func toFile(mock mock.Mock, filename string) error {
-	text, err := yaml.Marshal(mock)
+	text, err := mock.Marshal(mock.FileFormat)
 	if err != nil {
-		return errors.Wrap(err, "marshal mock to yaml")
+		return err
 	}
 
 	fileStats, err := os.Stat(filename)
@@ -167,5 +191,6 @@ func init() {
 	startCmd.Flags().StringArrayVarP(&filenames, "filename", "f", []string{}, "location of the mock file")
 	startCmd.Flags().IntVar(&adminPort, "admin-port", 2601, "port for admin API server")
 	startCmd.Flags().BoolVar(&filePersist, "persist", false, "save changes to files")
+	startCmd.Flags().BoolVar(&watchFiles, "watch", false, "reload mocks when their files change")
 	_ = startCmd.MarkFlagRequired("filename")
 }
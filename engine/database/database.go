@@ -3,17 +3,24 @@ package database
 import (
 	"context"
 
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/mock"
 )
 
 // EngineDB represents the database interface for the engine
 type EngineDB interface {
 	MockReadWriter
+	RequestJournal
+	StateReadWriter
 	GetInt(ctx context.Context, mockID, key string) (int, error)
 	Increment(ctx context.Context, mockID, key string) (int, error)
+	SetActiveSession(ctx context.Context, mockID string, sessionID string) error
+}
+
+// StateReadWriter represents the key value storage of the mock state, e.g. scenarios
+type StateReadWriter interface {
 	Set(ctx context.Context, mockID, key, value string) error
 	Get(ctx context.Context, mockID, key string) (string, error)
-	SetActiveSession(ctx context.Context, mockID string, sessionID string) error
 	GetActiveSession(ctx context.Context, mockID string) (string, error)
 }
 
@@ -22,6 +29,13 @@ type MockReadWriter interface {
 	SetMock(ctx context.Context, cfg *mock.Mock) error
 }
 
+// RequestJournal stores the requests received by the engine
+type RequestJournal interface {
+	AddRequest(ctx context.Context, entry *journal.Entry) error
+	GetRequests(ctx context.Context, mockID string, filter journal.Filter) ([]*journal.Entry, error)
+	ClearRequests(ctx context.Context, mockID string) error
+}
+
 type Database interface {
 	EngineDB
 	CRUD
@@ -30,6 +44,8 @@ type Database interface {
 // CRUD represents the database interface for the CRUD operations
 type CRUD interface {
 	MockReadWriter
+	RequestJournal
+	StateReadWriter
 	GetMocks(ctx context.Context) ([]*mock.Mock, error)
 	PatchRoute(ctx context.Context, mockID string, routeID string, data string) error
 	DeleteRoute(ctx context.Context, mockID string, routeID string) error
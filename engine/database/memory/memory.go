@@ -13,22 +13,28 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/mock"
 )
 
 var _ database.EngineDB = &Memory{}
 
+// maxRequests is the maximum number of requests kept in the journal per mock
+const maxRequests = 1000
+
 type Memory struct {
 	mu          sync.Mutex
 	configs     map[string]*mock.Mock
 	kv          map[string]any
+	requests    map[string][]*journal.Entry
 	subscribers []func(mock mock.Mock)
 }
 
 func New() *Memory {
 	return &Memory{
-		configs: map[string]*mock.Mock{},
-		kv:      map[string]any{},
+		configs:  map[string]*mock.Mock{},
+		kv:       map[string]any{},
+		requests: map[string][]*journal.Entry{},
 	}
 }
 
@@ -288,6 +294,35 @@ func (m *Memory) PatchResponse(ctx context.Context, mockID, routeID, responseID,
 	return nil
 }
 
+func (m *Memory) AddRequest(_ context.Context, entry *journal.Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := append(m.requests[entry.MockID], entry)
+	if len(entries) > maxRequests {
+		entries = entries[len(entries)-maxRequests:]
+	}
+	m.requests[entry.MockID] = entries
+
+	return nil
+}
+
+func (m *Memory) GetRequests(_ context.Context, mockID string, filter journal.Filter) ([]*journal.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return filter.Apply(m.requests[mockID]), nil
+}
+
+func (m *Memory) ClearRequests(_ context.Context, mockID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.requests, mockID)
+
+	return nil
+}
+
 func toActiveSessionKey(mockID string) string {
 	return fmt.Sprintf("%s-active-session", mockID)
 }
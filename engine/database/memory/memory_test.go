@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	. "github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/mock"
 )
 
@@ -285,3 +286,31 @@ func TestMemory_OnMockChanges(t *testing.T) {
 	_ = m.SetMock(context.Background(), cfg)
 	assert.Equal(t, updatedMock, *cfg)
 }
+
+func TestMemory_Requests(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	require.NoError(t, m.AddRequest(ctx, &journal.Entry{ID: "1", MockID: "mock1", Method: "GET"}))
+	require.NoError(t, m.AddRequest(ctx, &journal.Entry{ID: "2", MockID: "mock1", Method: "POST"}))
+	require.NoError(t, m.AddRequest(ctx, &journal.Entry{ID: "3", MockID: "mock2", Method: "GET"}))
+
+	entries, err := m.GetRequests(ctx, "mock1", journal.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = m.GetRequests(ctx, "mock1", journal.Filter{Method: "POST"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "2", entries[0].ID)
+
+	require.NoError(t, m.ClearRequests(ctx, "mock1"))
+
+	entries, err = m.GetRequests(ctx, "mock1", journal.Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = m.GetRequests(ctx, "mock2", journal.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
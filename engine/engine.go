@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
@@ -10,34 +11,75 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/matcher"
 	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/openapi"
 	"github.com/mockingio/mockingio/engine/plugins/faker"
+	"github.com/mockingio/mockingio/engine/plugins/templating"
+	"github.com/mockingio/mockingio/engine/recorder"
+	"github.com/mockingio/mockingio/engine/resource"
+	"github.com/mockingio/mockingio/engine/scenario"
 )
 
 type Engine struct {
-	mockID   string
-	isPaused bool
-	db       database.EngineDB
-	mock     *mock.Mock
-	plugins  []Plugin
+	mockID    string
+	isPaused  bool
+	db        database.EngineDB
+	mock      *mock.Mock
+	plugins   []Plugin
+	recordMu  sync.Mutex
+	resources *resource.Handler
+	// closed is closed when the engine stops, it ends the WebSockets, SSE streams and stalled requests
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	validatorMu   sync.Mutex
+	validator     *openapi.Validator
+	validatorFile string
+	validatorMock *mock.Mock
 }
 
 func New(mockID string, db database.EngineDB) *Engine {
 	return &Engine{
-		mockID:  mockID,
-		db:      db,
-		plugins: []Plugin{faker.New()},
+		mockID:    mockID,
+		db:        db,
+		plugins:   []Plugin{faker.New(), templating.New()},
+		resources: resource.New(db),
+		closed:    make(chan struct{}),
 	}
 }
 
+// Close ends the connections outliving their request, e.g. WebSockets, when the mock server stops
+func (eng *Engine) Close() {
+	eng.closeOnce.Do(func() {
+		close(eng.closed)
+	})
+}
+
+// untilClosed returns a context canceled when the engine closes, the server shutdown doesn't cancel requests
+func (eng *Engine) untilClosed(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-eng.closed:
+			cancel()
+		}
+	}()
+
+	return ctx, cancel
+}
+
 func (eng *Engine) Resume() {
 	eng.isPaused = false
 }
@@ -47,15 +89,21 @@ func (eng *Engine) Pause() {
 }
 
 func (eng *Engine) Match(req *http.Request) *mock.Response {
+	_, response, _ := eng.match(req)
+	return response
+}
+
+// match finds the matched route and response, together with the matching context
+func (eng *Engine) match(req *http.Request) (*mock.Route, *mock.Response, matcher.Context) {
 	ctx := req.Context()
 	if err := eng.reloadMock(ctx); err != nil {
 		log.WithError(err).Error("reload mock")
-		return nil
+		return nil, nil, matcher.Context{}
 	}
 
 	mok := eng.getMock()
 	if mok == nil {
-		return nil
+		return nil, nil, matcher.Context{}
 	}
 
 	sessionID, err := eng.db.GetActiveSession(ctx, eng.mockID)
@@ -63,12 +111,14 @@ func (eng *Engine) Match(req *http.Request) *mock.Response {
 		log.WithError(err).WithField("config_id", eng.mockID).Error("get active session")
 	}
 
+	matchingContext := matcher.Context{
+		HTTPRequest: req,
+		SessionID:   sessionID,
+	}
+
 	for _, route := range mok.Routes {
 		log.Debugf("Matching route: %v %v", route.Method, route.Path)
-		response, err := matcher.NewRouteMatcher(mok, route, matcher.Context{
-			HTTPRequest: req,
-			SessionID:   sessionID,
-		}, eng.db).Match()
+		response, err := matcher.NewRouteMatcher(mok, route, matchingContext, eng.db).Match()
 		if err != nil {
 			log.WithError(err).Error("matching route")
 			continue
@@ -79,70 +129,194 @@ func (eng *Engine) Match(req *http.Request) *mock.Response {
 			continue
 		}
 
+		if response.Scenario != "" && response.NewState != "" {
+			if err := scenario.SetState(ctx, eng.db, mok.ID, sessionID, response.Scenario, response.NewState); err != nil {
+				log.WithError(err).Error("set scenario state")
+			}
+		}
+
 		delay := response.Delay.Value()
 		if delay > 0 {
 			time.Sleep(time.Millisecond * time.Duration(delay))
 		}
 
-		return response
+		return route, response, matchingContext
 	}
 
-	return nil
+	return nil, nil, matchingContext
 }
 
 func (eng *Engine) Handler(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	body, err := matcher.ReadBody(r)
+	if err != nil {
+		log.WithError(err).Error("read request body")
+	}
+
+	writer := newResponseWriter(w)
+	route, response := eng.handle(writer, r)
+
+	eng.recordRequest(r, body, route, response, writer, start)
+}
+
+func (eng *Engine) handle(w http.ResponseWriter, r *http.Request) (*mock.Route, *mock.Response) {
 	if eng.isPaused {
 		eng.noMatchHandler(w)
-		return
+		return nil, nil
+	}
+
+	if fieldErrs, status := eng.validateRequest(r); len(fieldErrs) > 0 {
+		eng.invalidRequestHandler(w, r, fieldErrs, status)
+		return nil, nil
 	}
 
-	response := eng.Match(r)
+	route, response, matchingContext := eng.match(r)
 
 	mok := eng.getMock()
 	if mok == nil {
 		eng.noMatchHandler(w)
-		return
+		return nil, nil
 	}
 
 	if response == nil {
+		if wsRoute := webSocketRoute(mok, r); wsRoute != nil {
+			eng.serveWebSocket(w, mok, matchingContext, wsRoute)
+			return wsRoute, nil
+		}
+
 		if mok.AutoCORS && r.Method == http.MethodOptions {
 			eng.corsHandler(w, r)
-			return
+			return nil, nil
+		}
+
+		if eng.resources.Serve(w, r, mok, matchingContext.SessionID) {
+			return nil, nil
 		}
 
 		if mok.ProxyEnabled() {
 			eng.proxyHandler(w, r)
-			return
+			return nil, nil
 		}
 
 		eng.noMatchHandler(w)
-		return
+		return nil, nil
+	}
+
+	eng.serveResponse(w, mok, matchingContext, route, response)
+
+	return route, response
+}
+
+// recordRequest adds the served request to the request journal
+func (eng *Engine) recordRequest(
+	r *http.Request,
+	body []byte,
+	route *mock.Route,
+	response *mock.Response,
+	writer *responseWriter,
+	start time.Time,
+) {
+	ctx := r.Context()
+	sessionID, err := eng.db.GetActiveSession(ctx, eng.mockID)
+	if err != nil {
+		log.WithError(err).WithField("config_id", eng.mockID).Error("get active session")
+	}
+
+	entry := &journal.Entry{
+		ID:        uuid.NewString(),
+		MockID:    eng.mockID,
+		SessionID: sessionID,
+		Method:    r.Method,
+		URL:       r.RequestURI,
+		Host:      r.Host,
+		Path:      r.URL.Path,
+		Proto:     r.Proto,
+		Headers:   r.Header.Clone(),
+		Body:      string(truncate(body, maxJournalBody)),
+		Matched:   route != nil,
+		Status:    writer.status,
+		Timestamp: start,
+		Latency:   time.Since(start).Milliseconds(),
+
+		ResponseHeaders: writer.Header().Clone(),
+		ResponseBody:    writer.body.String(),
+	}
+	if entry.URL == "" {
+		entry.URL = r.URL.String()
+	}
+	entry.RemoteAddr = r.RemoteAddr
+	entry.Scheme = "http"
+	if r.TLS != nil {
+		entry.Scheme = "https"
+	}
+	if route != nil {
+		entry.RouteID = route.ID
+	}
+	if response != nil {
+		entry.ResponseID = response.ID
 	}
 
-	eng.serveResponse(w, mok, response)
+	if err := eng.db.AddRequest(ctx, entry); err != nil {
+		log.WithError(err).Error("add request to journal")
+	}
 }
 
-func (eng *Engine) serveResponse(w http.ResponseWriter, mok *mock.Mock, response *mock.Response) {
+func (eng *Engine) serveResponse(
+	w http.ResponseWriter,
+	mok *mock.Mock,
+	req matcher.Context,
+	route *mock.Route,
+	response *mock.Response,
+) {
+	// plugins modify headers in place, copy them to keep the mock untouched
+	headers := make(map[string]string, len(response.Headers))
+	for k, v := range response.Headers {
+		headers[k] = v
+	}
+	response.Headers = headers
+
 	for _, plugin := range eng.plugins {
-		plugin.Response(response)
+		plugin.Response(req, route, response)
+	}
+
+	fault := response.Fault
+	if fault == nil {
+		fault = mok.Fault
+	}
+
+	if fault != nil && fault.Happens() {
+		eng.serveFault(w, mok, req, route, fault, response)
+		return
 	}
 
 	for k, v := range response.Headers {
 		w.Header().Add(k, v)
 	}
 
+	ctx := req.HTTPRequest.Context()
+
+	if response.SSE != nil {
+		eng.serveSSE(ctx, w, req, route, response)
+		return
+	}
+
 	if response.FilePath != "" {
-		eng.serveStaticFile(w, mok, response.FilePath)
+		eng.serveStaticFile(ctx, w, mok, response)
 		return
 	}
 
+	body := []byte(response.Body)
+	if response.Throttle != nil {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		waitFirstByte(ctx, response.Throttle)
+	}
+
 	w.WriteHeader(response.Status)
-	_, _ = w.Write([]byte(response.Body))
-	return
+	writeBody(ctx, w, bytes.NewReader(body), int64(len(body)), response.Throttle)
 }
 
-func (eng *Engine) serveStaticFile(w http.ResponseWriter, mok *mock.Mock, filepath string) {
-	filepath = eng.getFilePath(mok, filepath)
+func (eng *Engine) serveStaticFile(ctx context.Context, w http.ResponseWriter, mok *mock.Mock, response *mock.Response) {
+	filepath := mok.ResolvePath(response.FilePath)
 	file, err := os.Open(filepath)
 	if err != nil {
 		log.WithError(err).Error("open file")
@@ -163,16 +337,10 @@ func (eng *Engine) serveStaticFile(w http.ResponseWriter, mok *mock.Mock, filepa
 	w.Header().Set("Content-Length", strconv.FormatInt(fileStat.Size(), 10))
 	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%v"`, path.Base(filepath)))
 
-	_, _ = file.Seek(0, 0)
-	_, _ = io.Copy(w, file)
-}
+	waitFirstByte(ctx, response.Throttle)
 
-func (eng *Engine) getFilePath(mok *mock.Mock, filepath string) string {
-	if path.IsAbs(filepath) {
-		return filepath
-	}
-
-	return path.Join(path.Dir(mok.FilePath), filepath)
+	_, _ = file.Seek(0, 0)
+	writeBody(ctx, w, file, fileStat.Size(), response.Throttle)
 }
 
 func (eng *Engine) noMatchHandler(w http.ResponseWriter) {
@@ -187,6 +355,11 @@ func (eng *Engine) corsHandler(w http.ResponseWriter, r *http.Request) {
 func (eng *Engine) proxyHandler(w http.ResponseWriter, r *http.Request) {
 	proxy := eng.getMock().Proxy
 
+	requestBody, err := matcher.ReadBody(r)
+	if err != nil {
+		log.WithError(err).Error("read request body")
+	}
+
 	req, err := copyProxyRequest(r, proxy)
 	if err != nil {
 		log.WithError(err).Error("copy request")
@@ -194,6 +367,12 @@ func (eng *Engine) proxyHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if proxy.RecordEnabled() {
+		// let the transport decompress the response, so the recorded body is readable
+		req.Header = req.Header.Clone()
+		req.Header.Del("Accept-Encoding")
+	}
+
 	client := &http.Client{}
 	if proxy.InsecureSkipVerify {
 		tr := &http.Transport{
@@ -210,9 +389,53 @@ func (eng *Engine) proxyHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer func() { _ = res.Body.Close() }()
 
+	if proxy.RecordEnabled() {
+		responseBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			log.WithError(err).Error("read proxy response")
+			eng.noMatchHandler(w)
+			return
+		}
+		res.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+		eng.record(r.Context(), recorder.Exchange{
+			Request:      r,
+			RequestBody:  requestBody,
+			Response:     res,
+			ResponseBody: responseBody,
+		})
+	}
+
 	writeProxyResponse(res, w, proxy)
 }
 
+// record saves the proxied exchange as a route of the mock. The exchange is recorded into a copy of the mock,
+// the mock being read by the requests served concurrently.
+func (eng *Engine) record(ctx context.Context, exchange recorder.Exchange) {
+	eng.recordMu.Lock()
+	defer eng.recordMu.Unlock()
+
+	current, err := eng.db.GetMock(ctx, eng.mockID)
+	if err != nil || current == nil {
+		log.WithError(err).Error("get mock to record")
+		return
+	}
+
+	mok, err := current.Clone()
+	if err != nil {
+		log.WithError(err).Error("copy mock to record")
+		return
+	}
+
+	if !recorder.Record(mok, mok.Proxy.Record, exchange) {
+		return
+	}
+
+	if err := eng.db.SetMock(ctx, mok); err != nil {
+		log.WithError(err).Error("save recorded route")
+	}
+}
+
 func (eng *Engine) getMock() *mock.Mock {
 	return eng.mock
 }
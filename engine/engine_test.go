@@ -3,21 +3,26 @@ package engine_test
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mockingio/mockingio/engine"
 	"github.com/mockingio/mockingio/engine/database"
 	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/journal"
 	"github.com/mockingio/mockingio/engine/mock"
 )
 
@@ -384,10 +389,12 @@ func setupMock() database.EngineDB {
 		AutoCORS: true,
 		Routes: []*mock.Route{
 			{
+				ID:     "route-id",
 				Method: "GET",
 				Path:   "/hello",
 				Responses: []mock.Response{
 					{
+						ID:     "response-id",
 						Status: 200,
 						Body:   "Hello World",
 						Headers: map[string]string{
@@ -461,3 +468,620 @@ func createTempFile(t *testing.T, dir, filename, content string) (string, func()
 		_ = os.Remove(file.Name())
 	}
 }
+
+func TestEngine_TemplateResponse(t *testing.T) {
+	mem := memory.New()
+	_ = mem.SetMock(context.Background(), &mock.Mock{
+		ID: "mock-id",
+		Routes: []*mock.Route{
+			{
+				Method: "GET",
+				Path:   "/users/:id",
+				Responses: []mock.Response{
+					{
+						Status:   200,
+						Headers:  map[string]string{"X-User-ID": `{{ param "id" }}`},
+						Body:     `{"id": "{{ param "id" }}"}`,
+						Template: true,
+					},
+				},
+			},
+		},
+	})
+
+	eng := engine.New("mock-id", mem)
+
+	for _, id := range []string{"1", "2"} {
+		w := httptest.NewRecorder()
+		eng.Handler(w, httptest.NewRequest(http.MethodGet, "/users/"+id, nil))
+		res := w.Result()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id": "`+id+`"}`, string(body))
+		assert.Equal(t, id, res.Header.Get("X-User-ID"))
+	}
+}
+
+func TestEngine_RequestJournal(t *testing.T) {
+	mem := setupMock()
+	_ = mem.SetActiveSession(context.Background(), "mock-id", "session-id")
+	eng := engine.New("mock-id", mem)
+
+	eng.Handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/hello?name=world", nil))
+	eng.Handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/not-found", strings.NewReader("payload")))
+	eng.Handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("a", 100<<10))))
+
+	entries, err := mem.GetRequests(context.Background(), "mock-id", journal.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "session-id", entries[0].SessionID)
+	assert.Equal(t, http.MethodGet, entries[0].Method)
+	assert.Equal(t, "/hello?name=world", entries[0].URL)
+	assert.Equal(t, "/hello", entries[0].Path)
+	assert.Equal(t, "route-id", entries[0].RouteID)
+	assert.Equal(t, "response-id", entries[0].ResponseID)
+	assert.Equal(t, http.StatusOK, entries[0].Status)
+	assert.True(t, entries[0].Matched)
+	assert.Equal(t, "example.com", entries[0].Host)
+	assert.Equal(t, "test", entries[0].ResponseHeaders.Get("X-Test"))
+	assert.Equal(t, "Hello World", entries[0].ResponseBody)
+
+	assert.Equal(t, "payload", entries[1].Body)
+	assert.Equal(t, http.StatusNotFound, entries[1].Status)
+	assert.False(t, entries[1].Matched)
+	assert.Empty(t, entries[1].RouteID)
+
+	// bodies are truncated to 64KB
+	assert.Len(t, entries[2].Body, 64<<10)
+}
+
+func TestEngine_ProxyRecord(t *testing.T) {
+	proxyServer := httptest.NewServer(proxyHandler(t))
+	defer proxyServer.Close()
+
+	mok := proxyMock(proxyServer.URL, false)
+	mok.Proxy.Record = &mock.Record{Enabled: true, MatchQueryStrings: true}
+
+	mem := memory.New()
+	_ = mem.SetMock(context.Background(), mok)
+	eng := engine.New("mock-id", mem)
+
+	w := httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/hello?name=world", nil))
+	assert.Equal(t, "From Proxy", w.Body.String())
+
+	recorded, err := mem.GetMock(context.Background(), "mock-id")
+	require.NoError(t, err)
+	require.Len(t, recorded.Routes, 1)
+	assert.Equal(t, "/hello", recorded.Routes[0].Path)
+	assert.Equal(t, "From Proxy", recorded.Routes[0].Responses[0].Body)
+	assert.Equal(t, "world", recorded.Routes[0].Responses[0].Rules[0].Value)
+
+	// the recorded route is served without the proxy
+	proxyServer.Close()
+	w = httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/hello?name=world", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "From Proxy", w.Body.String())
+	assert.Equal(t, "html/text", w.Header().Get("Content-Type"))
+}
+
+func TestEngine_Resources(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Resources = []*mock.Resource{{Path: "/products"}}
+	_ = mem.SetActiveSession(context.Background(), "mock-id", "session-1")
+	eng := engine.New("mock-id", mem)
+
+	w := httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"id": "1"}`)))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/products/1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// routes are matched before resources
+	w = httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	assert.Equal(t, "Hello World", w.Body.String())
+
+	// resetting the session resets the data
+	_ = mem.SetActiveSession(context.Background(), "mock-id", "session-2")
+	w = httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/products/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEngine_Scenario(t *testing.T) {
+	mem := memory.New()
+	_ = mem.SetMock(context.Background(), &mock.Mock{
+		ID: "mock-id",
+		Routes: []*mock.Route{
+			{
+				Method: "POST",
+				Path:   "/login",
+				Responses: []mock.Response{
+					{Status: 200, Body: "logged in", Scenario: "checkout", NewState: "logged_in"},
+				},
+			},
+			{
+				Method: "GET",
+				Path:   "/cart",
+				Responses: []mock.Response{
+					{Status: 200, Body: "cart", Scenario: "checkout", RequiredState: "logged_in", NewState: "cart"},
+					{Status: 401, Body: "unauthorized"},
+				},
+			},
+			{
+				Method: "POST",
+				Path:   "/checkout",
+				Responses: []mock.Response{
+					{Status: 201, Body: "paid", Scenario: "checkout", RequiredState: "cart", NewState: "paid"},
+					{Status: 400, Body: "empty cart"},
+				},
+			},
+		},
+	})
+	_ = mem.SetActiveSession(context.Background(), "mock-id", "session-1")
+	eng := engine.New("mock-id", mem)
+
+	serve := func(method, path string) string {
+		w := httptest.NewRecorder()
+		eng.Handler(w, httptest.NewRequest(method, path, nil))
+		return w.Body.String()
+	}
+
+	assert.Equal(t, "unauthorized", serve(http.MethodGet, "/cart"))
+	assert.Equal(t, "empty cart", serve(http.MethodPost, "/checkout"))
+	assert.Equal(t, "logged in", serve(http.MethodPost, "/login"))
+	assert.Equal(t, "cart", serve(http.MethodGet, "/cart"))
+	assert.Equal(t, "paid", serve(http.MethodPost, "/checkout"))
+	assert.Equal(t, "empty cart", serve(http.MethodPost, "/checkout"))
+
+	// a new session starts the scenario again
+	_ = mem.SetActiveSession(context.Background(), "mock-id", "session-2")
+	assert.Equal(t, "unauthorized", serve(http.MethodGet, "/cart"))
+}
+
+func TestEngine_Fault(t *testing.T) {
+	tests := []struct {
+		name     string
+		fault    *mock.Fault
+		assertFn func(t *testing.T, res *http.Response, err error)
+	}{
+		{
+			name:  "connection close",
+			fault: &mock.Fault{Type: mock.FaultConnectionClose},
+			assertFn: func(t *testing.T, res *http.Response, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			name:  "connection reset",
+			fault: &mock.Fault{Type: mock.FaultConnectionReset},
+			assertFn: func(t *testing.T, res *http.Response, err error) {
+				require.NoError(t, err)
+				_, err = io.ReadAll(res.Body)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name:  "truncated body",
+			fault: &mock.Fault{Type: mock.FaultTruncatedBody},
+			assertFn: func(t *testing.T, res *http.Response, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int64(len("Hello World")), res.ContentLength)
+				body, err := io.ReadAll(res.Body)
+				assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+				assert.Equal(t, "Hello", string(body))
+			},
+		},
+		{
+			name:  "malformed body",
+			fault: &mock.Fault{Type: mock.FaultMalformedBody},
+			assertFn: func(t *testing.T, res *http.Response, err error) {
+				require.NoError(t, err)
+				body, _ := io.ReadAll(res.Body)
+				assert.Len(t, body, len("Hello World"))
+				assert.NotEqual(t, "Hello World", string(body))
+			},
+		},
+		{
+			name:  "error status",
+			fault: &mock.Fault{Type: mock.FaultErrorStatus, Statuses: []int{503}},
+			assertFn: func(t *testing.T, res *http.Response, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+			},
+		},
+		{
+			name:  "stall",
+			fault: &mock.Fault{Type: mock.FaultStall},
+			assertFn: func(t *testing.T, res *http.Response, err error) {
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := setupMock()
+			mok, _ := mem.GetMock(context.Background(), "mock-id")
+			mok.Routes[0].Responses[0].Fault = tt.fault
+
+			srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+			defer srv.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/hello", nil)
+
+			res, err := http.DefaultClient.Do(req)
+			if res != nil {
+				defer func() { _ = res.Body.Close() }()
+			}
+			tt.assertFn(t, res, err)
+		})
+	}
+}
+
+func TestEngine_Fault_ResponseFile(t *testing.T) {
+	filename, closeFn := createTempFile(t, "mockingio", "test.json", `{"hello": "world"}`)
+	defer closeFn()
+
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes[0].Responses[0].Body = ""
+	mok.Routes[0].Responses[0].FilePath = filename
+	mok.Routes[0].Responses[0].Fault = &mock.Fault{Type: mock.FaultTruncatedBody}
+
+	srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/hello")
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+
+	assert.Equal(t, int64(len(`{"hello": "world"}`)), res.ContentLength)
+	body, err := io.ReadAll(res.Body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, `{"hello":`, string(body))
+}
+
+func TestEngine_Fault_StallEngineClose(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes[0].Responses[0].Fault = &mock.Fault{Type: mock.FaultStall}
+
+	eng := engine.New("mock-id", mem)
+	srv := httptest.NewServer(http.HandlerFunc(eng.Handler))
+	defer srv.Close()
+
+	done := make(chan struct{})
+	go func() {
+		res, err := http.Get(srv.URL + "/hello")
+		if err == nil {
+			_ = res.Body.Close()
+		}
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	eng.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the stalled request is not ended by the engine")
+	}
+}
+
+func TestEngine_MockFault(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Fault = &mock.Fault{Type: mock.FaultErrorStatus}
+
+	w := httptest.NewRecorder()
+	engine.New("mock-id", mem).Handler(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// the response fault takes precedence
+	mok.Routes[0].Responses[0].Fault = &mock.Fault{Type: mock.FaultErrorStatus, Statuses: []int{502}}
+	w = httptest.NewRecorder()
+	engine.New("mock-id", mem).Handler(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestEngine_OpenAPIValidation(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.FilePath = "openapi/fixtures/mock.yml"
+	mok.Validation = &mock.Validation{OpenAPI: "petstore.yaml"}
+	mok.Routes[0].Path = "/v1/pets"
+	eng := engine.New("mock-id", mem)
+
+	w := httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/v1/pets?limit=10", nil))
+	assert.Equal(t, "Hello World", w.Body.String())
+
+	w = httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/v1/pets?limit=1000", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"errors": [{"in": "query", "field": "limit", "message": "number must be at most 100"}]}`, w.Body.String())
+
+	mok.Validation.Status = http.StatusUnprocessableEntity
+	w = httptest.NewRecorder()
+	eng.Handler(w, httptest.NewRequest(http.MethodGet, "/v1/pets?limit=1000", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestEngine_Throttle(t *testing.T) {
+	body := strings.Repeat("a", 100)
+
+	tests := []struct {
+		name     string
+		throttle *mock.Throttle
+		filePath bool
+		minTTFB  time.Duration
+		minTotal time.Duration
+	}{
+		{
+			name:     "time to first byte",
+			throttle: &mock.Throttle{TimeToFirstByte: 100},
+			minTTFB:  100 * time.Millisecond,
+			minTotal: 100 * time.Millisecond,
+		},
+		{
+			name:     "bytes per second",
+			throttle: &mock.Throttle{BytesPerSecond: 500, ChunkSize: 50},
+			minTotal: 100 * time.Millisecond,
+		},
+		{
+			name:     "duration",
+			throttle: &mock.Throttle{Duration: 200, ChunkSize: 25},
+			minTotal: 200 * time.Millisecond,
+		},
+		{
+			name:     "file with chunk delay",
+			throttle: &mock.Throttle{TimeToFirstByte: 50, ChunkSize: 50, ChunkDelay: 100},
+			filePath: true,
+			minTTFB:  50 * time.Millisecond,
+			minTotal: 150 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := setupMock()
+			mok, _ := mem.GetMock(context.Background(), "mock-id")
+			response := &mok.Routes[0].Responses[0]
+			response.Throttle = tt.throttle
+			response.Body = body
+			if tt.filePath {
+				filename, closeFn := createTempFile(t, "mockingio-throttle", "body.txt", body)
+				defer closeFn()
+				response.FilePath = filename
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+			defer srv.Close()
+
+			start := time.Now()
+			res, err := http.Get(srv.URL + "/hello")
+			require.NoError(t, err)
+			defer func() { _ = res.Body.Close() }()
+			ttfb := time.Since(start)
+
+			data, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			total := time.Since(start)
+
+			assert.Equal(t, body, string(data))
+			assert.GreaterOrEqual(t, ttfb, tt.minTTFB)
+			assert.GreaterOrEqual(t, total, tt.minTotal)
+		})
+	}
+}
+
+func TestEngine_SSE(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes[0].Responses[0].Headers = nil
+	mok.Routes[0].Responses[0].Template = true
+	mok.Routes[0].Responses[0].SSE = &mock.SSE{
+		Events: []mock.Event{
+			{Event: "greeting", Data: "hello {{ query \"name\" }}", ID: "1"},
+			{Data: "bye", Delay: 50},
+		},
+		Repeat: 1,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+	defer srv.Close()
+
+	start := time.Now()
+	res, err := http.Get(srv.URL + "/hello?name=world")
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	events := "id: 1\nevent: greeting\ndata: hello world\n\ndata: bye\n\n"
+	assert.Equal(t, events+events, string(body))
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestEngine_SSE_Loop(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes[0].Responses[0].SSE = &mock.SSE{
+		Events: []mock.Event{{Data: "tick", Delay: 10}},
+		Loop:   true,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/hello", nil)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+
+	body, _ := io.ReadAll(res.Body)
+	assert.Greater(t, strings.Count(string(body), "data: tick\n\n"), 2)
+}
+
+func TestEngine_SSE_Loop_EngineClose(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes[0].Responses[0].SSE = &mock.SSE{
+		Events: []mock.Event{{Data: "tick", Delay: 10}},
+		Loop:   true,
+	}
+
+	eng := engine.New("mock-id", mem)
+	srv := httptest.NewServer(http.HandlerFunc(eng.Handler))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/hello")
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.ReadAll(res.Body)
+		close(done)
+	}()
+
+	eng.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the stream is not ended by the engine")
+	}
+}
+
+func TestEngine_WebSocket(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes = append(mok.Routes, &mock.Route{
+		ID:     "ws-route-id",
+		Method: "GET",
+		Path:   "/ws/:room",
+		WebSocket: &mock.WebSocket{
+			OnConnect: []mock.WebSocketMessage{{Data: `welcome to {{ param "room" }}`}},
+			Replies: []mock.Reply{
+				{
+					Rules:    []mock.Rule{{Target: mock.Message, Modifier: ".type", Operator: mock.Equal, Value: "ping"}},
+					Messages: []mock.WebSocketMessage{{Data: `pong {{ message ".id" }}`}},
+				},
+				{
+					Rules: []mock.Rule{{Target: mock.Message, Operator: mock.Equal, Value: "bye"}},
+					Close: &mock.Close{Code: 4000, Reason: "bye"},
+				},
+			},
+			Template: true,
+		},
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+	defer srv.Close()
+
+	conn, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/lobby", nil)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+
+	readMessage := func() string {
+		_, data, err := conn.ReadMessage()
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, "welcome to lobby", readMessage())
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "ping", "id": 7}`)))
+	assert.Equal(t, "pong 7", readMessage())
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("bye")))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, 4000), err)
+
+	// the HTTP routes are still served
+	w := httptest.NewRecorder()
+	engine.New("mock-id", mem).Handler(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	assert.Equal(t, "Hello World", w.Body.String())
+}
+
+func TestEngine_WebSocket_PushAndClose(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes = append(mok.Routes, &mock.Route{
+		Method: "GET",
+		Path:   "/ws",
+		WebSocket: &mock.WebSocket{
+			Pushes: []mock.Push{{Data: "tick", Delay: 10, Interval: 10}},
+			Close:  &mock.Close{Code: 4001, Delay: 100},
+		},
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(engine.New("mock-id", mem).Handler))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	ticks := 0
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			assert.True(t, websocket.IsCloseError(err, 4001), err)
+			break
+		}
+		assert.Equal(t, "tick", string(data))
+		ticks++
+	}
+	assert.Greater(t, ticks, 2)
+}
+
+func TestEngine_WebSocket_EngineClose(t *testing.T) {
+	mem := setupMock()
+	mok, _ := mem.GetMock(context.Background(), "mock-id")
+	mok.Routes = append(mok.Routes, &mock.Route{
+		Method: "GET",
+		Path:   "/ws",
+		WebSocket: &mock.WebSocket{
+			OnConnect: []mock.WebSocketMessage{{Data: "hello"}},
+		},
+	})
+
+	eng := engine.New("mock-id", mem)
+	srv := httptest.NewServer(http.HandlerFunc(eng.Handler))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	eng.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err = conn.ReadMessage()
+	require.Error(t, err)
+	var netErr net.Error
+	assert.False(t, errors.As(err, &netErr) && netErr.Timeout(), "the connection is closed by the engine")
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// serveFault breaks the response on purpose, as configured by the fault
+func (eng *Engine) serveFault(
+	w http.ResponseWriter,
+	mok *mock.Mock,
+	req matcher.Context,
+	route *mock.Route,
+	fault *mock.Fault,
+	response *mock.Response,
+) {
+	switch fault.Type {
+	case mock.FaultConnectionClose:
+		closeConnection(w, false)
+	case mock.FaultConnectionReset, mock.FaultTruncatedBody:
+		body := eng.faultBody(mok, req, route, response)
+		for k, v := range response.Headers {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(response.Status)
+		_, _ = w.Write(body[:len(body)/2])
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		closeConnection(w, fault.Type == mock.FaultConnectionReset)
+	case mock.FaultMalformedBody:
+		size := len(eng.faultBody(mok, req, route, response))
+		if size == 0 {
+			size = 64
+		}
+		garbage := make([]byte, size)
+		_, _ = rand.Read(garbage)
+
+		for k, v := range response.Headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(response.Status)
+		_, _ = w.Write(garbage)
+	case mock.FaultErrorStatus:
+		status := fault.Status()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(http.StatusText(status)))
+	case mock.FaultStall:
+		select {
+		case <-req.HTTPRequest.Context().Done():
+		case <-eng.closed:
+		}
+	}
+}
+
+// faultBody returns the body the response would send, the file of the response or its events
+func (eng *Engine) faultBody(mok *mock.Mock, req matcher.Context, route *mock.Route, response *mock.Response) []byte {
+	if response.FilePath != "" {
+		body, err := os.ReadFile(mok.ResolvePath(response.FilePath))
+		if err != nil {
+			log.WithError(err).Error("read file")
+		}
+		return body
+	}
+
+	if response.SSE != nil {
+		var sb strings.Builder
+		for _, event := range response.SSE.Events {
+			event.Data = eng.expand(req, route, event.Data, response.Template)
+			event.ID = eng.expand(req, route, event.ID, response.Template)
+			sb.WriteString(event.String())
+		}
+		return []byte(sb.String())
+	}
+
+	return []byte(response.Body)
+}
+
+// closeConnection closes the client connection, with a TCP reset if reset is true
+func closeConnection(w http.ResponseWriter, reset bool) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		log.Error("close connection: response writer doesn't support hijacking")
+		return
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		log.WithError(err).Error("hijack connection")
+		return
+	}
+
+	if reset {
+		netConn := conn
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			netConn = tlsConn.NetConn()
+		}
+		if tcpConn, ok := netConn.(*net.TCPConn); ok {
+			_ = tcpConn.SetLinger(0)
+		}
+	}
+
+	_ = conn.Close()
+}
@@ -0,0 +1,113 @@
+package grpcmock
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// Methods are the method descriptors, keyed by full method name, e.g. /helloworld.Greeter/SayHello
+type Methods map[string]protoreflect.MethodDescriptor
+
+// LoadMethods loads the method descriptors of the services declared in the proto files or the descriptor set
+func LoadMethods(ctx context.Context, mok *mock.Mock) (Methods, error) {
+	g := mok.GRPC
+	var files []protoreflect.FileDescriptor
+
+	if g.DescriptorSet != "" {
+		descriptors, err := loadDescriptorSet(mok.ResolvePath(g.DescriptorSet))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, descriptors...)
+	}
+
+	if len(g.ProtoFiles) > 0 {
+		descriptors, err := compileProtoFiles(ctx, mok)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, descriptors...)
+	}
+
+	result := Methods{}
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			serviceMethods := service.Methods()
+			for j := 0; j < serviceMethods.Len(); j++ {
+				method := serviceMethods.Get(j)
+				result[fmt.Sprintf("/%s/%s", service.FullName(), method.Name())] = method
+			}
+		}
+	}
+
+	for _, method := range g.Methods {
+		if _, ok := result[method.FullName()]; !ok {
+			return nil, fmt.Errorf("method %s not found in the proto descriptors", method.Name)
+		}
+	}
+
+	return result, nil
+}
+
+func loadDescriptorSet(filename string) ([]protoreflect.FileDescriptor, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "read descriptor set")
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, errors.Wrap(err, "unmarshal descriptor set")
+	}
+
+	registry, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, errors.Wrap(err, "build descriptors from set")
+	}
+
+	var files []protoreflect.FileDescriptor
+	registry.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		files = append(files, file)
+		return true
+	})
+
+	return files, nil
+}
+
+func compileProtoFiles(ctx context.Context, mok *mock.Mock) ([]protoreflect.FileDescriptor, error) {
+	importPaths := make([]string, 0, len(mok.GRPC.ImportPaths)+1)
+	for _, importPath := range mok.GRPC.ImportPaths {
+		importPaths = append(importPaths, mok.ResolvePath(importPath))
+	}
+	if len(importPaths) == 0 {
+		importPaths = append(importPaths, mok.ResolvePath("."))
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: importPaths}),
+	}
+
+	compiled, err := compiler.Compile(ctx, mok.GRPC.ProtoFiles...)
+	if err != nil {
+		return nil, errors.Wrap(err, "compile proto files")
+	}
+
+	files := make([]protoreflect.FileDescriptor, 0, len(compiled))
+	for _, file := range compiled {
+		files = append(files, file)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,15 @@
+
+
+greeter.proto
+helloworld"8
+HelloRequest
+name (	Rname
+times (Rtimes"&
+
+HelloReply
+message (	Rmessage2
+Greeter<
+SayHello.helloworld.HelloRequest.helloworld.HelloReplyD
+SayHelloStream.helloworld.HelloRequest.helloworld.HelloReply0C
+SayHelloToAll.helloworld.HelloRequest.helloworld.HelloReply(<
+Chat.helloworld.HelloRequest.helloworld.HelloReply(0bproto3
\ No newline at end of file
@@ -0,0 +1,300 @@
+package grpcmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/mockingio/mockingio/engine"
+	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/plugins/faker"
+	"github.com/mockingio/mockingio/engine/plugins/templating"
+)
+
+// requestEncoding encodes the request messages for the rules, with the field names of the proto files
+var requestEncoding = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// Handler serves the gRPC methods of a mock, the mock is read from the DB on every call
+type Handler struct {
+	mockID  string
+	db      database.EngineDB
+	methods Methods
+	plugins []engine.Plugin
+}
+
+func New(mockID string, db database.EngineDB, methods Methods) *Handler {
+	return &Handler{
+		mockID:  mockID,
+		db:      db,
+		methods: methods,
+		plugins: []engine.Plugin{faker.New(), templating.New()},
+	}
+}
+
+// NewServer builds the gRPC server of the mock, every method is served by the handler
+func NewServer(handler *Handler, opts ...grpc.ServerOption) *grpc.Server {
+	return grpc.NewServer(append(opts, grpc.UnknownServiceHandler(handler.Handle))...)
+}
+
+// Handle serves a call of any method, as a stream
+func (h *Handler) Handle(_ any, stream grpc.ServerStream) error {
+	ctx := stream.Context()
+
+	fullMethod, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "method not found in stream")
+	}
+
+	descriptor, ok := h.methods[fullMethod]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", fullMethod)
+	}
+
+	mok, err := h.db.GetMock(ctx, h.mockID)
+	if err != nil || mok == nil || mok.GRPC == nil {
+		return status.Error(codes.Unavailable, "mock not found")
+	}
+
+	method := findMethod(mok.GRPC, fullMethod)
+	if method == nil {
+		return status.Errorf(codes.Unimplemented, "method %s is not mocked", fullMethod)
+	}
+
+	call := &call{
+		handler:    h,
+		stream:     stream,
+		mock:       mok,
+		method:     method,
+		descriptor: descriptor,
+		route:      &mock.Route{ID: method.ID, Method: http.MethodPost, Path: fullMethod},
+	}
+
+	switch {
+	case descriptor.IsStreamingClient() && descriptor.IsStreamingServer():
+		// bidirectional streams reply to every message
+		for {
+			body, err := call.receive()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := call.respond(ctx, body); err != nil {
+				return err
+			}
+		}
+	case descriptor.IsStreamingClient():
+		// client streams are matched against the array of the received messages
+		var messages []json.RawMessage
+		for {
+			body, err := call.receive()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			messages = append(messages, body)
+		}
+
+		body, err := json.Marshal(messages)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return call.respond(ctx, body)
+	default:
+		body, err := call.receive()
+		if err != nil {
+			return err
+		}
+		return call.respond(ctx, body)
+	}
+}
+
+func findMethod(g *mock.GRPC, fullMethod string) *mock.GRPCMethod {
+	for _, method := range g.Methods {
+		if !method.Disabled && method.FullName() == fullMethod {
+			return method
+		}
+	}
+	return nil
+}
+
+type call struct {
+	handler     *Handler
+	stream      grpc.ServerStream
+	mock        *mock.Mock
+	method      *mock.GRPCMethod
+	descriptor  protoreflect.MethodDescriptor
+	route       *mock.Route
+	headersSent bool
+}
+
+// receive reads the next request message, encoded as JSON
+func (c *call) receive() ([]byte, error) {
+	message := dynamicpb.NewMessage(c.descriptor.Input())
+	if err := c.stream.RecvMsg(message); err != nil {
+		return nil, err
+	}
+
+	body, err := requestEncoding.Marshal(message)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return body, nil
+}
+
+// respond sends the response matching the request body and metadata
+func (c *call) respond(ctx context.Context, body []byte) error {
+	req, err := c.matchingContext(ctx, body)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	response, err := c.match(req)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if response == nil {
+		return status.Errorf(codes.NotFound, "no response matched for method %s", c.method.Name)
+	}
+
+	if delay := response.Delay.Value(); delay > 0 {
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+
+	if len(response.Metadata) > 0 && !c.headersSent {
+		if err := c.stream.SetHeader(metadata.New(c.expandAll(req, response.Metadata, response.Template))); err != nil {
+			log.WithError(err).Debug("set gRPC response headers")
+		}
+		c.headersSent = true
+	}
+	if len(response.Trailers) > 0 {
+		c.stream.SetTrailer(metadata.New(c.expandAll(req, response.Trailers, response.Template)))
+	}
+
+	if response.Code != int(codes.OK) {
+		return status.Error(codes.Code(response.Code), response.Message)
+	}
+
+	messages := response.Stream
+	if len(messages) == 0 || !c.descriptor.IsStreamingServer() {
+		messages = []mock.GRPCMessage{{Body: response.Body}}
+	}
+
+	for _, message := range messages {
+		if message.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-time.After(time.Duration(message.Delay) * time.Millisecond):
+			}
+		}
+
+		output := dynamicpb.NewMessage(c.descriptor.Output())
+		if err := c.unmarshal(c.expand(req, message.Body, response.Template), output); err != nil {
+			return status.Errorf(codes.Internal, "invalid response message: %v", err)
+		}
+
+		if err := c.stream.SendMsg(output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *call) unmarshal(body string, message proto.Message) error {
+	if body == "" {
+		return nil
+	}
+	return protojson.Unmarshal([]byte(body), message)
+}
+
+// matchingContext converts the call to an HTTP request, so the rules can be matched by the matcher package.
+// The metadata are the headers and the body is the JSON request message.
+func (c *call) matchingContext(ctx context.Context, body []byte) (matcher.Context, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.route.Path, bytes.NewReader(body))
+	if err != nil {
+		return matcher.Context{}, errors.Wrap(err, "build matching request")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	sessionID, err := c.handler.db.GetActiveSession(ctx, c.mock.ID)
+	if err != nil {
+		log.WithError(err).WithField("config_id", c.mock.ID).Error("get active session")
+	}
+
+	return matcher.Context{HTTPRequest: req, SessionID: sessionID}, nil
+}
+
+// match returns the default response among the matching ones, or the first one
+func (c *call) match(req matcher.Context) (*mock.GRPCResponse, error) {
+	db := c.handler.db
+	if _, err := db.Increment(req.HTTPRequest.Context(), c.mock.ID, req.CountID()); err != nil {
+		return nil, errors.Wrap(err, "increase request times")
+	}
+
+	var matched *mock.GRPCResponse
+	for i := range c.method.Responses {
+		response := &c.method.Responses[i]
+		ok, err := matcher.MatchRules(c.mock, c.route, response.RuleAggregation, response.Rules, req, db)
+		if err != nil {
+			return nil, errors.Wrap(err, "matching response")
+		}
+		if !ok {
+			continue
+		}
+
+		if response.IsDefault {
+			return response, nil
+		}
+		if matched == nil {
+			matched = response
+		}
+	}
+
+	return matched, nil
+}
+
+// expand runs the plugins on the value, as if it was a response body
+func (c *call) expand(req matcher.Context, value string, template bool) string {
+	response := &mock.Response{Body: value, Headers: map[string]string{}, Template: template}
+	for _, plugin := range c.handler.plugins {
+		plugin.Response(req, c.route, response)
+	}
+	return response.Body
+}
+
+func (c *call) expandAll(req matcher.Context, values map[string]string, template bool) map[string]string {
+	expanded := make(map[string]string, len(values))
+	for k, v := range values {
+		expanded[k] = c.expand(req, v, template)
+	}
+	return expanded
+}
@@ -0,0 +1,216 @@
+package grpcmock_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/grpcmock"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+func newMock() *mock.Mock {
+	return &mock.Mock{
+		ID:       "mock-id",
+		FilePath: "fixtures/mock.yml",
+		GRPC: &mock.GRPC{
+			ProtoFiles: []string{"greeter.proto"},
+			Methods: []*mock.GRPCMethod{
+				{
+					Name: "helloworld.Greeter/SayHello",
+					Responses: []mock.GRPCResponse{
+						{
+							Body:  `{"message": "hello admin"}`,
+							Rules: []mock.Rule{{Target: mock.Header, Modifier: "x-role", Operator: mock.Equal, Value: "admin"}},
+						},
+						{
+							Code:    int(codes.NotFound),
+							Message: "unknown user",
+							Rules:   []mock.Rule{{Target: mock.Body, Modifier: ".name", Operator: mock.Equal, Value: "nobody"}},
+						},
+						{
+							Body:     `{"message": "hello {{ body ".name" }}"}`,
+							Metadata: map[string]string{"x-mock": "true"},
+							Template: true,
+						},
+					},
+				},
+				{
+					Name: "helloworld.Greeter/SayHelloStream",
+					Responses: []mock.GRPCResponse{{
+						Stream: []mock.GRPCMessage{
+							{Body: `{"message": "one"}`},
+							{Body: `{"message": "two"}`, Delay: 10},
+						},
+					}},
+				},
+				{
+					Name: "helloworld.Greeter/SayHelloToAll",
+					Responses: []mock.GRPCResponse{{
+						Body:  `{"message": "hello everyone"}`,
+						Rules: []mock.Rule{{Target: mock.Body, Modifier: "length", Operator: mock.Equal, Value: "2"}},
+					}},
+				},
+				{
+					Name: "helloworld.Greeter/Chat",
+					Responses: []mock.GRPCResponse{{
+						Body:     `{"message": "echo {{ body ".name" }}"}`,
+						Template: true,
+					}},
+				},
+			},
+		},
+	}
+}
+
+func startServer(t *testing.T) (*grpc.ClientConn, grpcmock.Methods) {
+	mok := newMock()
+	require.NoError(t, mok.Validate())
+
+	methods, err := grpcmock.LoadMethods(context.Background(), mok)
+	require.NoError(t, err)
+
+	mem := memory.New()
+	require.NoError(t, mem.SetMock(context.Background(), mok))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpcmock.NewServer(grpcmock.New(mok.ID, mem, methods))
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, methods
+}
+
+func newRequest(t *testing.T, method protoreflect.MethodDescriptor, body string) *dynamicpb.Message {
+	message := dynamicpb.NewMessage(method.Input())
+	require.NoError(t, protojson.Unmarshal([]byte(body), message))
+	return message
+}
+
+func replyMessage(t *testing.T, message *dynamicpb.Message) string {
+	return message.Get(message.Descriptor().Fields().ByName("message")).String()
+}
+
+func TestHandler_Unary(t *testing.T) {
+	conn, methods := startServer(t)
+	method := methods["/helloworld.Greeter/SayHello"]
+
+	tests := []struct {
+		name     string
+		body     string
+		metadata metadata.MD
+		code     codes.Code
+		expected string
+	}{
+		{"template from request message", `{"name": "joe"}`, nil, codes.OK, "hello joe"},
+		{"rule on metadata", `{"name": "joe"}`, metadata.Pairs("x-role", "admin"), codes.OK, "hello admin"},
+		{"error status", `{"name": "nobody"}`, nil, codes.NotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.metadata)
+			reply := dynamicpb.NewMessage(method.Output())
+
+			var header metadata.MD
+			err := conn.Invoke(ctx, "/helloworld.Greeter/SayHello", newRequest(t, method, tt.body), reply, grpc.Header(&header))
+			assert.Equal(t, tt.code, status.Code(err), err)
+			if tt.code == codes.OK {
+				assert.Equal(t, tt.expected, replyMessage(t, reply))
+			}
+		})
+	}
+}
+
+func TestHandler_Streaming(t *testing.T) {
+	conn, methods := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("server streaming", func(t *testing.T) {
+		method := methods["/helloworld.Greeter/SayHelloStream"]
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/helloworld.Greeter/SayHelloStream")
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(newRequest(t, method, `{"name": "joe"}`)))
+		require.NoError(t, stream.CloseSend())
+
+		var messages []string
+		for {
+			reply := dynamicpb.NewMessage(method.Output())
+			if err := stream.RecvMsg(reply); err == io.EOF {
+				break
+			} else {
+				require.NoError(t, err)
+			}
+			messages = append(messages, replyMessage(t, reply))
+		}
+		assert.Equal(t, []string{"one", "two"}, messages)
+	})
+
+	t.Run("client streaming", func(t *testing.T) {
+		method := methods["/helloworld.Greeter/SayHelloToAll"]
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true}, "/helloworld.Greeter/SayHelloToAll")
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(newRequest(t, method, `{"name": "joe"}`)))
+		require.NoError(t, stream.SendMsg(newRequest(t, method, `{"name": "jane"}`)))
+		require.NoError(t, stream.CloseSend())
+
+		reply := dynamicpb.NewMessage(method.Output())
+		require.NoError(t, stream.RecvMsg(reply))
+		assert.Equal(t, "hello everyone", replyMessage(t, reply))
+	})
+
+	t.Run("bidirectional streaming", func(t *testing.T) {
+		method := methods["/helloworld.Greeter/Chat"]
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}, "/helloworld.Greeter/Chat")
+		require.NoError(t, err)
+
+		for _, name := range []string{"joe", "jane"} {
+			require.NoError(t, stream.SendMsg(newRequest(t, method, `{"name": "`+name+`"}`)))
+			reply := dynamicpb.NewMessage(method.Output())
+			require.NoError(t, stream.RecvMsg(reply))
+			assert.Equal(t, "echo "+name, replyMessage(t, reply))
+		}
+		require.NoError(t, stream.CloseSend())
+	})
+}
+
+func TestLoadMethods_DescriptorSet(t *testing.T) {
+	mok := newMock()
+	mok.GRPC.ProtoFiles = nil
+	mok.GRPC.DescriptorSet = "greeter.pb"
+	require.NoError(t, mok.Validate())
+
+	methods, err := grpcmock.LoadMethods(context.Background(), mok)
+	require.NoError(t, err)
+	assert.Len(t, methods, 4)
+	assert.True(t, methods["/helloworld.Greeter/Chat"].IsStreamingClient())
+}
+
+func TestLoadMethods_UnknownMethod(t *testing.T) {
+	mok := newMock()
+	mok.GRPC.Methods[0].Name = "helloworld.Greeter/Unknown"
+
+	_, err := grpcmock.LoadMethods(context.Background(), mok)
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package har
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mockingio/mockingio/engine/journal"
+)
+
+// FromJournal builds an HTTP archive of the requests received by the engine and the responses served,
+// the creator version is the version of mockingio
+func FromJournal(entries []*journal.Entry, creatorVersion string) *HAR {
+	h := &HAR{
+		Log: Log{
+			Version: "1.2",
+			Creator: Creator{Name: "mockingio", Version: creatorVersion},
+			Entries: []*Entry{},
+		},
+	}
+
+	for _, entry := range entries {
+		h.Log.Entries = append(h.Log.Entries, fromJournalEntry(entry))
+	}
+
+	return h
+}
+
+func fromJournalEntry(entry *journal.Entry) *Entry {
+	httpVersion := entry.Proto
+	if httpVersion == "" {
+		httpVersion = "HTTP/1.1"
+	}
+
+	request := Request{
+		Method:      entry.Method,
+		URL:         requestURL(entry),
+		HTTPVersion: httpVersion,
+		Cookies:     []NameValue{},
+		Headers:     toNameValues(entry.Headers),
+		QueryString: []NameValue{},
+		HeadersSize: -1,
+		BodySize:    len(entry.Body),
+	}
+
+	if u, err := url.Parse(entry.URL); err == nil {
+		request.QueryString = toNameValues(http.Header(u.Query()))
+	}
+
+	if entry.Body != "" {
+		request.PostData = &PostData{
+			MimeType: entry.Headers.Get("Content-Type"),
+			Text:     entry.Body,
+		}
+	}
+
+	response := Response{
+		Status:      entry.Status,
+		StatusText:  http.StatusText(entry.Status),
+		HTTPVersion: httpVersion,
+		Cookies:     []NameValue{},
+		Headers:     toNameValues(entry.ResponseHeaders),
+		Content: Content{
+			Size:     len(entry.ResponseBody),
+			MimeType: entry.ResponseHeaders.Get("Content-Type"),
+			Text:     entry.ResponseBody,
+		},
+		RedirectURL: entry.ResponseHeaders.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(entry.ResponseBody),
+	}
+
+	latency := float64(entry.Latency)
+
+	return &Entry{
+		StartedDateTime: entry.Timestamp,
+		Time:            latency,
+		Request:         request,
+		Response:        response,
+		Timings:         Timings{Wait: latency},
+	}
+}
+
+// requestURL returns the absolute URL of the request, the journal keeps the URL as received by the server
+func requestURL(entry *journal.Entry) string {
+	if entry.Host == "" || !strings.HasPrefix(entry.URL, "/") {
+		return entry.URL
+	}
+
+	return "http://" + entry.Host + entry.URL
+}
@@ -0,0 +1,66 @@
+package har_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mockingio/mockingio/engine/har"
+	"github.com/mockingio/mockingio/engine/journal"
+)
+
+func TestFromJournal(t *testing.T) {
+	timestamp := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	h := FromJournal([]*journal.Entry{
+		{
+			Method:          "POST",
+			URL:             "/products?page=1",
+			Host:            "localhost:8080",
+			Proto:           "HTTP/1.1",
+			Headers:         http.Header{"Content-Type": {"application/json"}},
+			Body:            `{"name": "book"}`,
+			Status:          201,
+			Timestamp:       timestamp,
+			Latency:         15,
+			ResponseHeaders: http.Header{"Content-Type": {"application/json"}, "X-Id": {"1"}},
+			ResponseBody:    `{"id": "1"}`,
+		},
+	}, "v1.0.0")
+
+	assert.Equal(t, "1.2", h.Log.Version)
+	assert.Equal(t, Creator{Name: "mockingio", Version: "v1.0.0"}, h.Log.Creator)
+	require.Len(t, h.Log.Entries, 1)
+
+	entry := h.Log.Entries[0]
+	assert.Equal(t, timestamp, entry.StartedDateTime)
+	assert.Equal(t, float64(15), entry.Time)
+
+	assert.Equal(t, "POST", entry.Request.Method)
+	assert.Equal(t, "http://localhost:8080/products?page=1", entry.Request.URL)
+	assert.Equal(t, []NameValue{{Name: "page", Value: "1"}}, entry.Request.QueryString)
+	assert.Equal(t, []NameValue{{Name: "Content-Type", Value: "application/json"}}, entry.Request.Headers)
+	assert.Equal(t, &PostData{MimeType: "application/json", Text: `{"name": "book"}`}, entry.Request.PostData)
+
+	assert.Equal(t, 201, entry.Response.Status)
+	assert.Equal(t, "Created", entry.Response.StatusText)
+	assert.Equal(t, []NameValue{
+		{Name: "Content-Type", Value: "application/json"},
+		{Name: "X-Id", Value: "1"},
+	}, entry.Response.Headers)
+	assert.Equal(t, Content{Size: 11, MimeType: "application/json", Text: `{"id": "1"}`}, entry.Response.Content)
+}
+
+func TestFromJournal_RoundTrip(t *testing.T) {
+	h := FromJournal([]*journal.Entry{
+		{Method: "GET", URL: "/hello", Host: "localhost", Status: 200, ResponseBody: "Hello World"},
+	}, "")
+
+	mok, err := ToMock(h)
+	require.NoError(t, err)
+	require.Len(t, mok.Routes, 1)
+	assert.Equal(t, "/hello", mok.Routes[0].Path)
+	assert.Equal(t, "Hello World", mok.Routes[0].Responses[0].Body)
+}
@@ -0,0 +1,118 @@
+package har
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/recorder"
+)
+
+// ImportFile builds a mock from an HTTP archive file
+func ImportFile(file string, opts ...mock.Option) (*mock.Mock, error) {
+	h, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToMock(h, opts...)
+}
+
+// ToMock builds a mock from the entries of an HTTP archive. Entries are deduplicated by method and path,
+// query string and body rules are added when the same endpoint has different responses.
+func ToMock(h *HAR, opts ...mock.Option) (*mock.Mock, error) {
+	m := mock.New(opts...)
+	cfg := &mock.Record{MatchQueryStrings: true, MatchBody: true}
+
+	for _, entry := range h.Log.Entries {
+		exchange, err := toExchange(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if exchange.Request.URL.Scheme != "http" && exchange.Request.URL.Scheme != "https" {
+			continue
+		}
+
+		if m.Name == "" {
+			m.Name = fmt.Sprintf("Imported from %s", exchange.Request.URL.Host)
+		}
+
+		recorder.Record(m, cfg, exchange)
+	}
+
+	for _, route := range m.Routes {
+		if sameResponses(route.Responses) {
+			response := route.Responses[0]
+			response.Rules = nil
+			response.RuleAggregation = ""
+			route.Responses = []mock.Response{response}
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid imported mock")
+	}
+
+	return m, nil
+}
+
+func toExchange(entry *Entry) (recorder.Exchange, error) {
+	var requestBody []byte
+	if entry.Request.PostData != nil {
+		requestBody = []byte(entry.Request.PostData.Text)
+	}
+
+	req, err := http.NewRequest(entry.Request.Method, entry.Request.URL, bytes.NewReader(requestBody))
+	if err != nil {
+		return recorder.Exchange{}, errors.Wrapf(err, "invalid request %v %v", entry.Request.Method, entry.Request.URL)
+	}
+	for _, header := range entry.Request.Headers {
+		req.Header.Add(header.Name, header.Value)
+	}
+
+	responseBody := []byte(entry.Response.Content.Text)
+	if entry.Response.Content.Encoding == "base64" {
+		if responseBody, err = base64.StdEncoding.DecodeString(entry.Response.Content.Text); err != nil {
+			return recorder.Exchange{}, errors.Wrapf(err, "decode response of %v %v", entry.Request.Method, entry.Request.URL)
+		}
+	}
+
+	res := &http.Response{
+		StatusCode: entry.Response.Status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(responseBody)),
+	}
+	for _, header := range entry.Response.Headers {
+		// HTTP/2 pseudo headers, e.g. :status, are not response headers
+		if header.Name == "" || header.Name[0] == ':' {
+			continue
+		}
+		res.Header.Add(header.Name, header.Value)
+	}
+
+	return recorder.Exchange{
+		Request:      req,
+		RequestBody:  requestBody,
+		Response:     res,
+		ResponseBody: responseBody,
+	}, nil
+}
+
+// sameResponses returns true if the responses only differ by their rules
+func sameResponses(responses []mock.Response) bool {
+	first := responses[0]
+	for _, response := range responses[1:] {
+		if first.Status != response.Status || first.Body != response.Body || !reflect.DeepEqual(first.Headers, response.Headers) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package har_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mockingio/mockingio/engine/har"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestImportFile(t *testing.T) {
+	mok, err := ImportFile("fixtures/session.har")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Imported from api.example.com", mok.Name)
+	require.Len(t, mok.Routes, 2)
+
+	products := mok.Routes[0]
+	assert.Equal(t, "GET", products.Method)
+	assert.Equal(t, "/products", products.Path)
+	require.Len(t, products.Responses, 2)
+
+	first := products.Responses[0]
+	assert.Equal(t, 200, first.Status)
+	assert.Equal(t, `[{"id": "1"}]`, first.Body)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, first.Headers)
+	assert.Equal(t, mock.And, first.RuleAggregation)
+	require.Len(t, first.Rules, 1)
+	assert.Equal(t, mock.QueryString, first.Rules[0].Target)
+	assert.Equal(t, "page", first.Rules[0].Modifier)
+	assert.Equal(t, "1", first.Rules[0].Value)
+
+	second := products.Responses[1]
+	assert.Equal(t, "[]", second.Body)
+	require.Len(t, second.Rules, 1)
+	assert.Equal(t, "2", second.Rules[0].Value)
+
+	login := mok.Routes[1]
+	assert.Equal(t, "POST", login.Method)
+	assert.Equal(t, "/login", login.Path)
+	require.Len(t, login.Responses, 1)
+	assert.Equal(t, 204, login.Responses[0].Status)
+	assert.Empty(t, login.Responses[0].Rules, "an endpoint with one response has no rules")
+}
+
+func TestToMock_SameResponses(t *testing.T) {
+	entry := func(url string) *Entry {
+		return &Entry{
+			Request:  Request{Method: "GET", URL: url},
+			Response: Response{Status: 200, Content: Content{Text: "ok"}},
+		}
+	}
+
+	mok, err := ToMock(&HAR{Log: Log{Entries: []*Entry{
+		entry("http://localhost/hello?name=joe"),
+		entry("http://localhost/hello?name=jane"),
+	}}})
+	require.NoError(t, err)
+
+	require.Len(t, mok.Routes, 1)
+	require.Len(t, mok.Routes[0].Responses, 1)
+	assert.Empty(t, mok.Routes[0].Responses[0].Rules)
+}
+
+func TestImportFile_Error(t *testing.T) {
+	_, err := ImportFile("fixtures/not-found.har")
+	assert.Error(t, err)
+
+	_, err = Read([]byte("not json"))
+	assert.Error(t, err)
+}
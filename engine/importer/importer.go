@@ -0,0 +1,55 @@
+// Package importer translates mocks of other tools into mocks
+package importer
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// routeSet groups the imported responses into routes, by method and path
+type routeSet struct {
+	mock   *mock.Mock
+	routes map[string]*mock.Route
+}
+
+func newRouteSet(mok *mock.Mock) *routeSet {
+	return &routeSet{mock: mok, routes: map[string]*mock.Route{}}
+}
+
+func (s *routeSet) add(method, path, description string, response mock.Response) {
+	key := routeKey(method, path)
+	route, ok := s.routes[key]
+	if !ok {
+		route = &mock.Route{
+			Method:      strings.ToUpper(method),
+			Path:        path,
+			Description: description,
+		}
+		s.routes[key] = route
+		s.mock.Routes = append(s.mock.Routes, route)
+	}
+
+	route.Responses = append(route.Responses, response)
+}
+
+// hasRules returns true if the route of the method and path has a response with the same rules
+func (s *routeSet) hasRules(method, path string, rules []mock.Rule) bool {
+	route, ok := s.routes[routeKey(method, path)]
+	if !ok {
+		return false
+	}
+
+	for _, response := range route.Responses {
+		if len(response.Rules) == 0 && len(rules) == 0 || reflect.DeepEqual(response.Rules, rules) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
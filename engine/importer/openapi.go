@@ -0,0 +1,376 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// maxSchemaDepth stops the body synthesis of recursive schemas
+const maxSchemaDepth = 8
+
+var (
+	openAPIParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+	openAPIMethods      = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+		http.MethodOptions,
+	}
+)
+
+// FromOpenAPI builds a mock from an OpenAPI 3 spec in YAML or JSON, with a route per operation
+// and a response per documented status code
+func FromOpenAPI(data []byte) (*mock.Mock, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "load OpenAPI spec")
+	}
+
+	return fromOpenAPIDoc(doc)
+}
+
+// FromOpenAPIFile builds a mock from an OpenAPI 3 spec file, references to other files are resolved
+func FromOpenAPIFile(file string) (*mock.Mock, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	doc, err := loader.LoadFromFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "load OpenAPI spec")
+	}
+
+	return fromOpenAPIDoc(doc)
+}
+
+func fromOpenAPIDoc(doc *openapi3.T) (*mock.Mock, error) {
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, errors.Wrap(err, "invalid OpenAPI spec")
+	}
+
+	m := mock.New()
+	if doc.Info != nil {
+		m.Name = doc.Info.Title
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, method := range openAPIMethods {
+			operation := item.GetOperation(method)
+			if operation == nil {
+				continue
+			}
+
+			route := &mock.Route{
+				Method:      method,
+				Path:        OpenAPIPath(path),
+				Description: operation.Summary,
+				Responses:   openAPIResponses(operation),
+			}
+			if len(route.Responses) == 0 {
+				continue
+			}
+
+			m.Routes = append(m.Routes, route)
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid imported mock")
+	}
+
+	return m, nil
+}
+
+// OpenAPIPath converts the OpenAPI path params to route params, e.g. /users/{id} to /users/:id
+func OpenAPIPath(path string) string {
+	return openAPIParamPattern.ReplaceAllString(path, ":$1")
+}
+
+func openAPIResponses(operation *openapi3.Operation) []mock.Response {
+	type statusResponse struct {
+		status int
+		ref    *openapi3.ResponseRef
+	}
+
+	// sorted codes have the explicit codes before their range, e.g. 400 before 4XX,
+	// a range is skipped if its status is documented explicitly
+	codes := make([]string, 0, len(operation.Responses))
+	for code := range operation.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var statuses []statusResponse
+	used := map[int]bool{}
+	for _, code := range codes {
+		ref := operation.Responses[code]
+		status, ok := openAPIStatus(code)
+		if !ok || used[status] || ref == nil || ref.Value == nil {
+			continue
+		}
+		statuses = append(statuses, statusResponse{status: status, ref: ref})
+		used[status] = true
+	}
+
+	// the default response is a 200 if it is the only one, the first unused 5xx status otherwise
+	if ref := operation.Responses.Default(); ref != nil && ref.Value != nil {
+		status := http.StatusOK
+		if len(statuses) > 0 {
+			status = http.StatusInternalServerError
+			for used[status] && status < 599 {
+				status++
+			}
+		}
+		if !used[status] {
+			statuses = append(statuses, statusResponse{status: status, ref: ref})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].status < statuses[j].status
+	})
+
+	var responses []mock.Response
+	hasDefault := false
+	for _, s := range statuses {
+		response := mock.Response{
+			Status:  s.status,
+			Headers: map[string]string{},
+		}
+
+		if contentType, mediaType := pickMediaType(s.ref.Value.Content); mediaType != nil {
+			response.Headers["Content-Type"] = contentType
+			response.Body = openAPIBody(contentType, mediaType)
+		}
+
+		if !hasDefault && s.status >= 200 && s.status < 300 {
+			response.IsDefault = true
+			hasDefault = true
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
+// openAPIStatus converts a response code, ranges like 4XX use the first status.
+// The default response is converted by the caller.
+func openAPIStatus(code string) (int, bool) {
+	if len(code) == 3 && strings.HasSuffix(strings.ToUpper(code), "XX") {
+		code = code[:1] + "00"
+	}
+
+	status, err := strconv.Atoi(code)
+	if err != nil || status < 100 || status > 999 {
+		return 0, false
+	}
+
+	return status, true
+}
+
+// pickMediaType prefers JSON media types, then the first one in alphabetical order
+func pickMediaType(content openapi3.Content) (string, *openapi3.MediaType) {
+	if len(content) == 0 {
+		return "", nil
+	}
+
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	for _, contentType := range contentTypes {
+		if isJSONContentType(contentType) {
+			return contentType, content[contentType]
+		}
+	}
+
+	return contentTypes[0], content[contentTypes[0]]
+}
+
+func isJSONContentType(contentType string) bool {
+	return strings.Contains(contentType, "/json") || strings.Contains(contentType, "+json")
+}
+
+// openAPIBody returns the example of the media type, or a body synthesized from its schema
+func openAPIBody(contentType string, mediaType *openapi3.MediaType) string {
+	value := mediaTypeExample(mediaType)
+	if value == nil && mediaType.Schema != nil {
+		value = SchemaExample(mediaType.Schema.Value)
+	}
+	if value == nil {
+		return ""
+	}
+
+	if s, ok := value.(string); ok && !isJSONContentType(contentType) {
+		return s
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+func mediaTypeExample(mediaType *openapi3.MediaType) any {
+	if mediaType.Example != nil {
+		return mediaType.Example
+	}
+
+	names := make([]string, 0, len(mediaType.Examples))
+	for name := range mediaType.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if example := mediaType.Examples[name]; example != nil && example.Value != nil && example.Value.Value != nil {
+			return example.Value.Value
+		}
+	}
+
+	return nil
+}
+
+// SchemaExample synthesizes a value from the schema, strings without example are faker placeholders
+func SchemaExample(schema *openapi3.Schema) any {
+	return schemaExample(schema, "", 0)
+}
+
+func schemaExample(schema *openapi3.Schema, name string, depth int) any {
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		merged := map[string]any{}
+		for _, ref := range schema.AllOf {
+			if ref == nil {
+				continue
+			}
+			if object, ok := schemaExample(ref.Value, name, depth+1).(map[string]any); ok {
+				for k, v := range object {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	case len(schema.OneOf) > 0 && schema.OneOf[0] != nil:
+		return schemaExample(schema.OneOf[0].Value, name, depth+1)
+	case len(schema.AnyOf) > 0 && schema.AnyOf[0] != nil:
+		return schemaExample(schema.AnyOf[0].Value, name, depth+1)
+	}
+
+	switch schema.Type {
+	case openapi3.TypeArray:
+		if schema.Items == nil {
+			return []any{}
+		}
+		item := schemaExample(schema.Items.Value, name, depth+1)
+		if item == nil {
+			return []any{}
+		}
+		return []any{item}
+	case openapi3.TypeInteger:
+		if schema.Min != nil {
+			return int64(*schema.Min)
+		}
+		return 1
+	case openapi3.TypeNumber:
+		if schema.Min != nil {
+			return *schema.Min
+		}
+		return 1.5
+	case openapi3.TypeBoolean:
+		return true
+	case openapi3.TypeString:
+		return stringExample(schema.Format, name)
+	case openapi3.TypeObject, "":
+		if schema.Type == "" && len(schema.Properties) == 0 {
+			return nil
+		}
+
+		object := map[string]any{}
+		for property, ref := range schema.Properties {
+			if ref == nil {
+				continue
+			}
+			if value := schemaExample(ref.Value, property, depth+1); value != nil {
+				object[property] = value
+			}
+		}
+		return object
+	}
+
+	return nil
+}
+
+// stringExample returns a fixed value for date formats, and a faker placeholder for the others
+func stringExample(format, name string) string {
+	switch format {
+	case "date":
+		return "2022-01-01"
+	case "date-time":
+		return "2022-01-01T00:00:00Z"
+	case "email":
+		return "${faker.internet.email}"
+	case "uuid":
+		return "${faker.uuid.v4}"
+	case "uri", "url":
+		return "${faker.internet.url}"
+	case "ipv4":
+		return "${faker.internet.ipv4}"
+	}
+
+	if name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "Id") {
+		return "${faker.uuid.v4}"
+	}
+
+	if placeholder, ok := namePlaceholders[strings.ToLower(strings.ReplaceAll(name, "_", ""))]; ok {
+		return placeholder
+	}
+
+	return "${faker.lorem.word}"
+}
+
+// namePlaceholders are the faker placeholders of common property names, without underscores
+var namePlaceholders = map[string]string{
+	"name":      "${faker.person.name}",
+	"fullname":  "${faker.person.name}",
+	"firstname": "${faker.person.firstname}",
+	"lastname":  "${faker.person.lastname}",
+	"email":     "${faker.internet.email}",
+	"url":       "${faker.internet.url}",
+	"website":   "${faker.internet.url}",
+	"phone":     "${faker.phone.number}",
+	"city":      "${faker.address.city}",
+	"country":   "${faker.address.country}",
+}
@@ -0,0 +1,159 @@
+package importer_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mockingio/mockingio/engine/importer"
+)
+
+func TestFromOpenAPIFile(t *testing.T) {
+	mok, err := FromOpenAPIFile("fixtures/openapi.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Pet store", mok.Name)
+	require.Len(t, mok.Routes, 3)
+
+	list := mok.Routes[0]
+	assert.Equal(t, "GET", list.Method)
+	assert.Equal(t, "/pets", list.Path)
+	assert.Equal(t, "List pets", list.Description)
+	require.Len(t, list.Responses, 1)
+	assert.True(t, list.Responses[0].IsDefault)
+	assert.Equal(t, "application/json", list.Responses[0].Headers["Content-Type"])
+
+	var pets []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(list.Responses[0].Body), &pets))
+	assert.Equal(t, []map[string]any{{
+		"id":     float64(1),
+		"name":   "${faker.person.name}",
+		"email":  "${faker.internet.email}",
+		"status": "available",
+		"tags":   []any{"${faker.lorem.word}"},
+	}}, pets)
+
+	create := mok.Routes[1]
+	assert.Equal(t, "POST", create.Method)
+	require.Len(t, create.Responses, 2)
+	assert.Equal(t, 201, create.Responses[0].Status)
+	assert.True(t, create.Responses[0].IsDefault)
+	assert.JSONEq(t, `{"id": 1, "name": "Rex"}`, create.Responses[0].Body)
+	assert.Equal(t, 400, create.Responses[1].Status)
+	assert.False(t, create.Responses[1].IsDefault)
+	assert.JSONEq(t, `{"error": "name is required"}`, create.Responses[1].Body)
+
+	get := mok.Routes[2]
+	assert.Equal(t, "/pets/:petId", get.Path)
+	require.Len(t, get.Responses, 3)
+	assert.Equal(t, 200, get.Responses[0].Status)
+	assert.Equal(t, 404, get.Responses[1].Status)
+	assert.Empty(t, get.Responses[1].Body)
+	assert.Equal(t, 500, get.Responses[2].Status)
+	assert.Equal(t, "text/plain", get.Responses[2].Headers["Content-Type"])
+	assert.Equal(t, "something went wrong", get.Responses[2].Body)
+}
+
+func TestFromOpenAPI_DefaultResponse(t *testing.T) {
+	mok, err := FromOpenAPI([]byte(`
+openapi: 3.0.0
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      responses:
+        "200": {description: users}
+        "500": {description: server error}
+        "501": {description: not implemented}
+        default: {description: error}
+  /health:
+    get:
+      responses:
+        default: {description: health}
+`))
+	require.NoError(t, err)
+	require.Len(t, mok.Routes, 2)
+
+	var statuses []int
+	for _, response := range mok.Routes[1].Responses {
+		statuses = append(statuses, response.Status)
+	}
+	assert.Equal(t, "/users", mok.Routes[1].Path)
+	assert.Equal(t, []int{200, 500, 501, 502}, statuses)
+
+	assert.Equal(t, "/health", mok.Routes[0].Path)
+	require.Len(t, mok.Routes[0].Responses, 1)
+	assert.Equal(t, 200, mok.Routes[0].Responses[0].Status)
+}
+
+func TestFromOpenAPI_StatusRange(t *testing.T) {
+	mok, err := FromOpenAPI([]byte(`
+openapi: 3.0.0
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      responses:
+        "200": {description: users}
+        "4XX":
+          description: client error
+          content:
+            application/json:
+              example: {error: range}
+        "400":
+          description: bad request
+          content:
+            application/json:
+              example: {error: explicit}
+        "5XX": {description: server error}
+`))
+	require.NoError(t, err)
+	require.Len(t, mok.Routes, 1)
+
+	responses := mok.Routes[0].Responses
+	require.Len(t, responses, 3)
+	assert.Equal(t, []int{200, 400, 500}, []int{responses[0].Status, responses[1].Status, responses[2].Status})
+	assert.JSONEq(t, `{"error": "explicit"}`, responses[1].Body)
+}
+
+func TestFromOpenAPI_Invalid(t *testing.T) {
+	_, err := FromOpenAPI([]byte(`openapi: 3.0.0`))
+	assert.Error(t, err)
+
+	_, err = FromOpenAPI([]byte(`not: [valid`))
+	assert.Error(t, err)
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	assert.Equal(t, "/users/:id/posts/:post_id", OpenAPIPath("/users/{id}/posts/{post_id}"))
+	assert.Equal(t, "/users", OpenAPIPath("/users"))
+}
+
+func TestSchemaExample(t *testing.T) {
+	min := 10.0
+	tests := []struct {
+		name     string
+		schema   *openapi3.Schema
+		expected any
+	}{
+		{"example", &openapi3.Schema{Type: "string", Example: "hello"}, "hello"},
+		{"integer minimum", &openapi3.Schema{Type: "integer", Min: &min}, int64(10)},
+		{"boolean", &openapi3.Schema{Type: "boolean"}, true},
+		{"uuid", &openapi3.Schema{Type: "string", Format: "uuid"}, "${faker.uuid.v4}"},
+		{"date-time", &openapi3.Schema{Type: "string", Format: "date-time"}, "2022-01-01T00:00:00Z"},
+		{"one of", &openapi3.Schema{OneOf: openapi3.SchemaRefs{openapi3.NewSchemaRef("", openapi3.NewBoolSchema())}}, true},
+		{"all of", &openapi3.Schema{AllOf: openapi3.SchemaRefs{
+			openapi3.NewSchemaRef("", openapi3.NewObjectSchema().WithProperty("a", openapi3.NewBoolSchema())),
+			openapi3.NewSchemaRef("", openapi3.NewObjectSchema().WithProperty("b", openapi3.NewBoolSchema())),
+		}}, map[string]any{"a": true, "b": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SchemaExample(tt.schema))
+		})
+	}
+}
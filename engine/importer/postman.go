@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// postmanVariable is a Postman variable, e.g. {{baseUrl}}
+var postmanVariable = regexp.MustCompile(`{{\s*([^{}]+?)\s*}}`)
+
+type postmanCollection struct {
+	Info struct {
+		Name string `json:"name"`
+	} `json:"info"`
+	Item []*postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name     string             `json:"name"`
+	Item     []*postmanItem     `json:"item"`
+	Request  *postmanRequest    `json:"request"`
+	Response []*postmanResponse `json:"response"`
+	Event    []json.RawMessage  `json:"event"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanKeyValue `json:"header"`
+	URL    postmanURL        `json:"url"`
+}
+
+// UnmarshalJSON reads a request, which can be a URL only
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*r = postmanRequest{Method: "GET", URL: postmanURL{Raw: raw}}
+		return nil
+	}
+
+	type request postmanRequest
+	return json.Unmarshal(data, (*request)(r))
+}
+
+type postmanURL struct {
+	Raw   string            `json:"raw"`
+	Path  json.RawMessage   `json:"path"`
+	Query []postmanKeyValue `json:"query"`
+}
+
+// UnmarshalJSON reads a URL, which can be a string
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*u = postmanURL{Raw: raw}
+		return nil
+	}
+
+	type postmanURLObject postmanURL
+	return json.Unmarshal(data, (*postmanURLObject)(u))
+}
+
+type postmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+type postmanResponse struct {
+	Name            string            `json:"name"`
+	OriginalRequest *postmanRequest   `json:"originalRequest"`
+	Code            int               `json:"code"`
+	Header          []postmanKeyValue `json:"header"`
+	Body            string            `json:"body"`
+}
+
+// FromPostmanFile builds a mock from a Postman collection file
+func FromPostmanFile(file string) (*mock.Mock, *Report, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read Postman collection")
+	}
+
+	return FromPostman(data)
+}
+
+// FromPostman builds a mock from the saved example responses of a Postman collection (v2.0 or v2.1).
+// Examples of the same method and path are responses of one route, with query string rules.
+func FromPostman(data []byte) (*mock.Mock, *Report, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal Postman collection")
+	}
+
+	report := &Report{}
+	m := mock.New()
+	m.Name = collection.Info.Name
+	routes := newRouteSet(m)
+
+	var walk func(prefix string, items []*postmanItem)
+	walk = func(prefix string, items []*postmanItem) {
+		for _, item := range items {
+			source := prefix + item.Name
+			if len(item.Event) > 0 {
+				report.add(source, "scripts are not translated")
+			}
+
+			if item.Request == nil {
+				walk(source+" / ", item.Item)
+				continue
+			}
+
+			if len(item.Response) == 0 {
+				report.add(source, "the request has no saved example, it is skipped")
+				continue
+			}
+
+			for _, example := range item.Response {
+				addPostmanExample(routes, report, source+" / "+example.Name, item, example)
+			}
+		}
+	}
+	walk("", collection.Item)
+
+	for _, route := range m.Routes {
+		if len(route.Responses) == 1 {
+			route.Responses[0].Rules = nil
+			route.Responses[0].RuleAggregation = ""
+			continue
+		}
+
+		// examples with query strings are more specific, they are matched first
+		sort.SliceStable(route.Responses, func(i, j int) bool {
+			return len(route.Responses[i].Rules) > len(route.Responses[j].Rules)
+		})
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid imported mock")
+	}
+
+	return m, report, nil
+}
+
+func addPostmanExample(routes *routeSet, report *Report, source string, item *postmanItem, example *postmanResponse) {
+	request := example.OriginalRequest
+	if request == nil {
+		request = item.Request
+	}
+
+	method := request.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	path, query := postmanPath(request.URL)
+
+	response := mock.Response{
+		Status:  example.Code,
+		Headers: map[string]string{},
+		Body:    example.Body,
+	}
+	if response.Status == 0 {
+		report.add(source, "the example has no status code, 200 is used")
+		response.Status = 200
+	}
+
+	for _, header := range example.Header {
+		if header.Disabled || strings.EqualFold(header.Key, "Content-Length") {
+			continue
+		}
+		response.Headers[header.Key] = header.Value
+	}
+
+	if postmanVariable.MatchString(example.Body) {
+		report.add(source, "variables of the body are not resolved")
+	}
+
+	for _, param := range query {
+		if param.Disabled || param.Key == "" {
+			continue
+		}
+		response.Rules = append(response.Rules, newRule(mock.QueryString, param.Key, mock.Equal, param.Value))
+	}
+	if len(response.Rules) > 0 {
+		response.RuleAggregation = mock.And
+	}
+
+	// responses with the same rules are served in the order of the examples
+	if routes.hasRules(method, path, response.Rules) {
+		report.add(source, "another example of the request has the same query string, the example is never served")
+	}
+
+	routes.add(method, path, item.Name, response)
+}
+
+// postmanPath returns the route path and the query string of a Postman URL, variables become route params
+func postmanPath(u postmanURL) (string, []postmanKeyValue) {
+	var segments []string
+	if err := json.Unmarshal(u.Path, &segments); err != nil {
+		var path string
+		if err := json.Unmarshal(u.Path, &path); err == nil {
+			segments = strings.Split(strings.Trim(path, "/"), "/")
+		}
+	}
+
+	query := u.Query
+	if segments == nil {
+		raw := u.Raw
+		if i := strings.Index(raw, "?"); i >= 0 {
+			if values, err := url.ParseQuery(raw[i+1:]); err == nil && query == nil {
+				for _, key := range sortedKeys(values) {
+					query = append(query, postmanKeyValue{Key: key, Value: values.Get(key)})
+				}
+			}
+			raw = raw[:i]
+		}
+
+		// the first part is the host, e.g. {{baseUrl}} or https://api.example.com
+		raw = strings.TrimPrefix(strings.TrimPrefix(raw, "https://"), "http://")
+		parts := strings.Split(raw, "/")
+		if len(parts) > 1 {
+			segments = parts[1:]
+		}
+	}
+
+	for i, segment := range segments {
+		if match := postmanVariable.FindStringSubmatch(segment); match != nil && match[0] == segment {
+			segments[i] = ":" + match[1]
+		}
+	}
+
+	return "/" + strings.Join(segments, "/"), query
+}
@@ -0,0 +1,85 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mockingio/mockingio/engine/importer"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestFromPostmanFile(t *testing.T) {
+	mok, report, err := FromPostmanFile("fixtures/postman.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Shop", mok.Name)
+	require.Len(t, mok.Routes, 3)
+
+	products := mok.Routes[0]
+	assert.Equal(t, "GET", products.Method)
+	assert.Equal(t, "/products", products.Path)
+	assert.Equal(t, "List products", products.Description)
+	require.Len(t, products.Responses, 2)
+
+	firstPage := products.Responses[0]
+	assert.Equal(t, `[{"id": "1"}]`, firstPage.Body)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, firstPage.Headers)
+	assert.Equal(t, mock.And, firstPage.RuleAggregation)
+	assert.Equal(t, []mock.Rule{{Target: mock.QueryString, Modifier: "page", Operator: mock.Equal, Value: "1"}}, firstPage.Rules)
+	assert.Equal(t, "[]", products.Responses[1].Body)
+	assert.Empty(t, products.Responses[1].Rules)
+
+	product := mok.Routes[1]
+	assert.Equal(t, "/products/:id", product.Path)
+	assert.Equal(t, 200, product.Responses[0].Status)
+
+	// a route with one example has no rules, Postman variables are route params
+	user := mok.Routes[2]
+	assert.Equal(t, "/users/:userId", user.Path)
+	assert.Empty(t, user.Responses[0].Rules)
+
+	assert.Equal(t, []ReportItem{
+		{Source: "Products / List products", Message: "scripts are not translated"},
+		{Source: "Products / Get product / found", Message: "variables of the body are not resolved"},
+		{Source: "Delete user", Message: "the request has no saved example, it is skipped"},
+	}, report.Items)
+}
+
+func TestFromPostman_UnreachableExamples(t *testing.T) {
+	mok, report, err := FromPostman([]byte(`{
+		"info": {"name": "Users"},
+		"item": [{
+			"name": "Get user",
+			"request": {"method": "GET", "url": "{{baseUrl}}/users/1"},
+			"response": [
+				{"name": "found", "code": 200, "body": "joe"},
+				{"name": "not found", "code": 404},
+				{"name": "admin", "originalRequest": {"method": "GET", "url": "{{baseUrl}}/users/1?role=admin"}, "code": 200, "body": "admin"}
+			]
+		}]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, mok.Routes, 1)
+	require.Len(t, mok.Routes[0].Responses, 3)
+	assert.Equal(t, "admin", mok.Routes[0].Responses[0].Body)
+	assert.Equal(t, "joe", mok.Routes[0].Responses[1].Body)
+
+	assert.Equal(t, []ReportItem{
+		{Source: "Get user / not found", Message: "another example of the request has the same query string, the example is never served"},
+	}, report.Items)
+}
+
+func TestFromPostman_Error(t *testing.T) {
+	_, _, err := FromPostman([]byte("not json"))
+	assert.Error(t, err)
+
+	_, _, err = FromPostmanFile("fixtures/not-found.json")
+	assert.Error(t, err)
+}
+
+func TestReport_String(t *testing.T) {
+	report := Report{Items: []ReportItem{{Source: "mapping", Message: "not translated"}}}
+	assert.Equal(t, "mapping: not translated\n", report.String())
+}
@@ -0,0 +1,30 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report lists what could not be translated, or was only approximated, by an import
+type Report struct {
+	Items []ReportItem `json:"items"`
+}
+
+// ReportItem is a part of the source which could not be translated
+type ReportItem struct {
+	// Source identifies the part of the source, e.g. a WireMock mapping or a Postman example
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+func (r *Report) add(source, format string, args ...any) {
+	r.Items = append(r.Items, ReportItem{Source: source, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, item := range r.Items {
+		_, _ = fmt.Fprintf(&b, "%s: %s\n", item.Source, item.Message)
+	}
+	return b.String()
+}
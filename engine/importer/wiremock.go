@@ -0,0 +1,567 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/scenario"
+)
+
+// maxDelay is the longest response delay of a mock, in milliseconds
+const maxDelay = 60
+
+// wireMockStarted is the initial state of WireMock scenarios
+const wireMockStarted = "Started"
+
+// wireMockMethods are the methods a mapping with the ANY method is translated to
+var wireMockMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+type wireMockMappings struct {
+	Mappings []*wireMockMapping `json:"mappings"`
+}
+
+type wireMockMapping struct {
+	ID                    string           `json:"id"`
+	UUID                  string           `json:"uuid"`
+	Name                  string           `json:"name"`
+	Priority              int              `json:"priority"`
+	ScenarioName          string           `json:"scenarioName"`
+	RequiredScenarioState string           `json:"requiredScenarioState"`
+	NewScenarioState      string           `json:"newScenarioState"`
+	Request               wireMockRequest  `json:"request"`
+	Response              wireMockResponse `json:"response"`
+}
+
+type wireMockRequest struct {
+	Method               string                     `json:"method"`
+	URL                  string                     `json:"url"`
+	URLPath              string                     `json:"urlPath"`
+	URLPattern           string                     `json:"urlPattern"`
+	URLPathPattern       string                     `json:"urlPathPattern"`
+	QueryParameters      map[string]wireMockMatcher `json:"queryParameters"`
+	Headers              map[string]wireMockMatcher `json:"headers"`
+	Cookies              map[string]wireMockMatcher `json:"cookies"`
+	BodyPatterns         []wireMockMatcher          `json:"bodyPatterns"`
+	BasicAuthCredentials *struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"basicAuthCredentials"`
+}
+
+// wireMockMatcher is a WireMock value matcher, e.g. {"equalTo": "value", "caseInsensitive": true}
+type wireMockMatcher map[string]json.RawMessage
+
+type wireMockResponse struct {
+	Status                 int                        `json:"status"`
+	Headers                map[string]json.RawMessage `json:"headers"`
+	Body                   string                     `json:"body"`
+	JSONBody               json.RawMessage            `json:"jsonBody"`
+	Base64Body             string                     `json:"base64Body"`
+	BodyFileName           string                     `json:"bodyFileName"`
+	FixedDelayMilliseconds int                        `json:"fixedDelayMilliseconds"`
+	DelayDistribution      *struct {
+		Type  string `json:"type"`
+		Lower int    `json:"lower"`
+		Upper int    `json:"upper"`
+	} `json:"delayDistribution"`
+	ChunkedDribbleDelay json.RawMessage `json:"chunkedDribbleDelay"`
+	Fault               string          `json:"fault"`
+	Transformers        []string        `json:"transformers"`
+	ProxyBaseURL        string          `json:"proxyBaseUrl"`
+}
+
+// wireMockFaults are the WireMock faults with an equivalent fault
+var wireMockFaults = map[string]mock.FaultType{
+	"CONNECTION_RESET_BY_PEER": mock.FaultConnectionReset,
+	"EMPTY_RESPONSE":           mock.FaultConnectionClose,
+	"MALFORMED_RESPONSE_CHUNK": mock.FaultMalformedBody,
+	"RANDOM_DATA_THEN_CLOSE":   mock.FaultMalformedBody,
+}
+
+// wireMockImport is a WireMock import in progress
+type wireMockImport struct {
+	report *Report
+	// filesDir is the __files directory, where the response body files are
+	filesDir string
+}
+
+// importedMapping is a translated mapping, before it is added to the routes
+type importedMapping struct {
+	methods     []string
+	path        string
+	description string
+	priority    int
+	response    mock.Response
+}
+
+// FromWireMockFiles builds a mock from WireMock mapping files. Directories are read as WireMock root
+// directories, with the mappings in mappings/*.json and the body files in __files.
+func FromWireMockFiles(paths ...string) (*mock.Mock, *Report, error) {
+	var mappings []*wireMockMapping
+	filesDir := ""
+
+	for _, path := range paths {
+		files := []string{path}
+
+		if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+			mappingsDir := filepath.Join(path, "mappings")
+			if _, err := os.Stat(mappingsDir); err != nil {
+				mappingsDir = path
+			}
+			if files, err = filepath.Glob(filepath.Join(mappingsDir, "*.json")); err != nil {
+				return nil, nil, errors.Wrap(err, "list mapping files")
+			}
+			sort.Strings(files)
+			filesDir = filepath.Join(path, "__files")
+		} else if filesDir == "" {
+			filesDir = filepath.Join(filepath.Dir(filepath.Dir(path)), "__files")
+		}
+
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "read mapping file")
+			}
+
+			fileMappings, err := readWireMockMappings(data)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "parse mapping file %v", file)
+			}
+			mappings = append(mappings, fileMappings...)
+		}
+	}
+
+	if absDir, err := filepath.Abs(filesDir); err == nil {
+		filesDir = absDir
+	}
+
+	return fromWireMockMappings(mappings, filesDir)
+}
+
+// FromWireMock builds a mock from a WireMock mapping, or a list of mappings
+func FromWireMock(data []byte) (*mock.Mock, *Report, error) {
+	mappings, err := readWireMockMappings(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fromWireMockMappings(mappings, "__files")
+}
+
+func readWireMockMappings(data []byte) ([]*wireMockMapping, error) {
+	var list wireMockMappings
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrap(err, "unmarshal WireMock mappings")
+	}
+
+	if list.Mappings != nil {
+		return list.Mappings, nil
+	}
+
+	var single wireMockMapping
+	if err := json.Unmarshal(data, &single); err != nil {
+		return nil, errors.Wrap(err, "unmarshal WireMock mapping")
+	}
+
+	return []*wireMockMapping{&single}, nil
+}
+
+func fromWireMockMappings(mappings []*wireMockMapping, filesDir string) (*mock.Mock, *Report, error) {
+	w := &wireMockImport{report: &Report{}, filesDir: filesDir}
+
+	var imported []importedMapping
+	for i, mapping := range mappings {
+		if m, ok := w.translate(wireMockSource(i, mapping), mapping); ok {
+			imported = append(imported, m)
+		}
+	}
+
+	// WireMock serves the mapping with the highest priority, the lowest number, then the most recently added one
+	for i, j := 0, len(imported)-1; i < j; i, j = i+1, j-1 {
+		imported[i], imported[j] = imported[j], imported[i]
+	}
+	sort.SliceStable(imported, func(i, j int) bool {
+		return imported[i].priority < imported[j].priority
+	})
+
+	m := mock.New()
+	m.Name = "Imported from WireMock"
+	routes := newRouteSet(m)
+	for _, mapping := range imported {
+		for _, method := range mapping.methods {
+			routes.add(method, mapping.path, mapping.description, mapping.response)
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid imported mock")
+	}
+
+	return m, w.report, nil
+}
+
+func wireMockSource(i int, mapping *wireMockMapping) string {
+	for _, name := range []string{mapping.Name, mapping.ID, mapping.UUID} {
+		if name != "" {
+			return fmt.Sprintf("mapping %q", name)
+		}
+	}
+	return fmt.Sprintf("mapping #%d", i+1)
+}
+
+func (w *wireMockImport) translate(source string, mapping *wireMockMapping) (importedMapping, bool) {
+	req := mapping.Request
+
+	if mapping.Response.ProxyBaseURL != "" {
+		w.report.add(source, "proxy responses are not supported, the mapping is skipped")
+		return importedMapping{}, false
+	}
+
+	imported := importedMapping{
+		description: mapping.Name,
+		priority:    mapping.Priority,
+		response:    w.response(source, mapping.Response),
+	}
+	if imported.priority == 0 {
+		imported.priority = 5
+	}
+
+	switch method := strings.ToUpper(req.Method); method {
+	case "", "ANY":
+		imported.methods = wireMockMethods
+	default:
+		imported.methods = []string{method}
+	}
+
+	var rules []mock.Rule
+	switch {
+	case req.URL != "":
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			w.report.add(source, "invalid url %v, the mapping is skipped", req.URL)
+			return importedMapping{}, false
+		}
+		imported.path = u.Path
+		query := u.Query()
+		for _, name := range sortedKeys(query) {
+			rules = append(rules, newRule(mock.QueryString, name, mock.Equal, query.Get(name)))
+		}
+	case req.URLPath != "":
+		imported.path = req.URLPath
+	case req.URLPattern != "" || req.URLPathPattern != "":
+		pattern := req.URLPattern + req.URLPathPattern
+		path, exact := patternToPath(pattern)
+		if !exact {
+			w.report.add(source, "url pattern %v is approximated as %v", pattern, path)
+		}
+		imported.path = path
+	default:
+		imported.path = "*"
+	}
+
+	for _, name := range sortedKeys(req.QueryParameters) {
+		rules = append(rules, w.rules(source, mock.QueryString, name, req.QueryParameters[name])...)
+	}
+	for _, name := range sortedKeys(req.Headers) {
+		rules = append(rules, w.rules(source, mock.Header, name, req.Headers[name])...)
+	}
+	for _, name := range sortedKeys(req.Cookies) {
+		rules = append(rules, w.rules(source, mock.Cookie, name, req.Cookies[name])...)
+	}
+	for _, pattern := range req.BodyPatterns {
+		rules = append(rules, w.bodyRules(source, pattern)...)
+	}
+
+	if auth := req.BasicAuthCredentials; auth != nil {
+		credentials := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+		rules = append(rules, newRule(mock.Header, "Authorization", mock.Equal, "Basic "+credentials))
+	}
+
+	imported.response.Rules = rules
+	if len(rules) > 0 {
+		imported.response.RuleAggregation = mock.And
+	}
+
+	if mapping.ScenarioName != "" {
+		imported.response.Scenario = mapping.ScenarioName
+		imported.response.RequiredState = scenarioState(mapping.RequiredScenarioState)
+		imported.response.NewState = scenarioState(mapping.NewScenarioState)
+	}
+
+	return imported, true
+}
+
+func (w *wireMockImport) response(source string, res wireMockResponse) mock.Response {
+	response := mock.Response{
+		Status:  res.Status,
+		Headers: map[string]string{},
+		Body:    res.Body,
+	}
+	if response.Status == 0 {
+		response.Status = 200
+	}
+
+	for _, name := range sortedKeys(res.Headers) {
+		var values []string
+		if err := json.Unmarshal(res.Headers[name], &values); err != nil {
+			var value string
+			_ = json.Unmarshal(res.Headers[name], &value)
+			values = []string{value}
+		}
+		response.Headers[name] = strings.Join(values, ", ")
+	}
+
+	switch {
+	case len(res.JSONBody) > 0:
+		response.Body = compactJSON(res.JSONBody)
+	case res.Base64Body != "":
+		body, err := base64.StdEncoding.DecodeString(res.Base64Body)
+		if err != nil {
+			w.report.add(source, "invalid base64 body is not translated")
+		}
+		response.Body = string(body)
+	case res.BodyFileName != "":
+		response.FilePath = filepath.Join(w.filesDir, res.BodyFileName)
+	}
+
+	delay := res.FixedDelayMilliseconds
+	if d := res.DelayDistribution; d != nil {
+		if d.Type == "uniform" {
+			response.Delay = mock.Delay{Min: d.Lower, Max: d.Upper}
+		} else {
+			w.report.add(source, "%v delay distribution is not supported", d.Type)
+		}
+	}
+	if delay > 0 {
+		response.Delay = mock.Delay{Min: delay, Max: delay}
+	}
+	if response.Delay.Max > maxDelay {
+		w.report.add(source, "delay of %dms is capped to %dms", response.Delay.Max, maxDelay)
+		response.Delay.Max = maxDelay
+		if response.Delay.Min > maxDelay {
+			response.Delay.Min = maxDelay
+		}
+	}
+
+	if res.Fault != "" {
+		if faultType, ok := wireMockFaults[res.Fault]; ok {
+			response.Fault = &mock.Fault{Type: faultType}
+		} else {
+			w.report.add(source, "fault %v is not supported", res.Fault)
+		}
+	}
+
+	if len(res.ChunkedDribbleDelay) > 0 {
+		w.report.add(source, "chunked dribble delay is not translated, use a response throttle instead")
+	}
+
+	if len(res.Transformers) > 0 {
+		w.report.add(source, "response transformers %v are not translated", strings.Join(res.Transformers, ", "))
+	}
+
+	return response
+}
+
+// rules translates the matcher of a query parameter, header or cookie
+func (w *wireMockImport) rules(source string, target mock.Target, name string, matcher wireMockMatcher) []mock.Rule {
+	caseInsensitive := false
+	_ = json.Unmarshal(matcher["caseInsensitive"], &caseInsensitive)
+
+	var rules []mock.Rule
+	for _, key := range sortedKeys(matcher) {
+		var value string
+		_ = json.Unmarshal(matcher[key], &value)
+
+		switch key {
+		case "caseInsensitive":
+		case "equalTo":
+			if caseInsensitive {
+				rules = append(rules, newRule(target, name, mock.EqualIgnoreCase, value))
+			} else {
+				rules = append(rules, newRule(target, name, mock.Equal, value))
+			}
+		case "matches":
+			rules = append(rules, newRule(target, name, mock.Regex, anchored(value)))
+		case "contains":
+			rules = append(rules, newRule(target, name, mock.Contains, value))
+		case "absent":
+			absent := false
+			_ = json.Unmarshal(matcher[key], &absent)
+			if absent {
+				rules = append(rules, newRule(target, name, mock.Absent, ""))
+			} else {
+				rules = append(rules, newRule(target, name, mock.Exists, ""))
+			}
+		default:
+			w.report.add(source, "%v matcher of %v %v is not translated", key, target, name)
+		}
+	}
+
+	return rules
+}
+
+// bodyRules translates a body pattern
+func (w *wireMockImport) bodyRules(source string, pattern wireMockMatcher) []mock.Rule {
+	var ignoreArrayOrder, ignoreExtraElements bool
+	_ = json.Unmarshal(pattern["ignoreArrayOrder"], &ignoreArrayOrder)
+	_ = json.Unmarshal(pattern["ignoreExtraElements"], &ignoreExtraElements)
+
+	var rules []mock.Rule
+	for _, key := range sortedKeys(pattern) {
+		raw := pattern[key]
+
+		switch key {
+		case "ignoreArrayOrder", "ignoreExtraElements":
+		case "caseInsensitive":
+			w.report.add(source, "caseInsensitive of the body is not translated")
+		case "equalToJson":
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				value = string(raw)
+			}
+			if ignoreExtraElements {
+				rule := newRule(mock.Body, "", mock.JSONContains, compactJSON([]byte(value)))
+				rule.IgnoreArrayOrder = ignoreArrayOrder
+				rules = append(rules, rule)
+				continue
+			}
+			if ignoreArrayOrder {
+				w.report.add(source, "ignoreArrayOrder of the JSON body is only translated with ignoreExtraElements, the body must be equal")
+			}
+			rules = append(rules, newRule(mock.Body, "", mock.Equal, compactJSON([]byte(value))))
+		case "equalTo":
+			rules = append(rules, newRule(mock.Body, "", mock.Equal, rawString(raw)))
+		case "matches":
+			rules = append(rules, newRule(mock.Body, "", mock.Regex, anchored(rawString(raw))))
+		case "contains":
+			rules = append(rules, newRule(mock.Body, "", mock.Contains, rawString(raw)))
+		case "matchesJsonPath":
+			rules = append(rules, w.jsonPathRules(source, raw)...)
+		default:
+			w.report.add(source, "%v body pattern is not translated", key)
+		}
+	}
+
+	return rules
+}
+
+// jsonPathRules translates a matchesJsonPath pattern, either an expression or an expression with a matcher
+func (w *wireMockImport) jsonPathRules(source string, raw json.RawMessage) []mock.Rule {
+	var expression string
+	var matcher wireMockMatcher
+	if err := json.Unmarshal(raw, &expression); err != nil {
+		if err := json.Unmarshal(raw, &matcher); err != nil {
+			w.report.add(source, "invalid matchesJsonPath is not translated")
+			return nil
+		}
+		expression = rawString(matcher["expression"])
+		delete(matcher, "expression")
+	}
+
+	query, ok := jsonPathToQuery(expression)
+	if !ok {
+		w.report.add(source, "JSON path %v is not translated", expression)
+		return nil
+	}
+
+	if len(matcher) == 0 {
+		// the JSON path must exist
+		return []mock.Rule{newRule(mock.Body, query, mock.Exists, "")}
+	}
+
+	return w.rules(source, mock.Body, query, matcher)
+}
+
+// patternToPath converts a URL regex into a route path, the segments with a regex become wildcards.
+// It returns false if the path is an approximation of the regex.
+func patternToPath(pattern string) (string, bool) {
+	exact := true
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+
+	if i := strings.Index(pattern, `\?`); i >= 0 {
+		pattern, exact = pattern[:i], false
+	}
+
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		re, err := syntax.Parse(segment, syntax.Perl)
+		if err == nil && re.Op == syntax.OpLiteral {
+			segments[i] = string(re.Rune)
+			continue
+		}
+		if err == nil && re.Op == syntax.OpEmptyMatch {
+			continue
+		}
+		segments[i], exact = "*", false
+	}
+
+	return strings.Join(segments, "/"), exact
+}
+
+// jsonPathToQuery converts a simple JSON path, e.g. $.items[0].name, into a gojq query
+func jsonPathToQuery(path string) (string, bool) {
+	if !strings.HasPrefix(path, "$") || strings.ContainsAny(path, "?*@()") || strings.Contains(path, "..") {
+		return "", false
+	}
+
+	query := strings.TrimPrefix(path, "$")
+	query = regexp.MustCompile(`\['([^']*)'\]`).ReplaceAllString(query, `["$1"]`)
+	if query == "" || query[0] == '[' {
+		query = "." + query
+	}
+
+	return query, true
+}
+
+func newRule(target mock.Target, modifier string, operator mock.Operator, value string) mock.Rule {
+	return mock.Rule{Target: target, Modifier: modifier, Operator: operator, Value: value}
+}
+
+func scenarioState(state string) string {
+	if state == wireMockStarted {
+		return scenario.Started
+	}
+	return state
+}
+
+// anchored makes a regex match the whole value, as WireMock does
+func anchored(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+func rawString(raw json.RawMessage) string {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw)
+	}
+	return value
+}
+
+func compactJSON(data []byte) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		return string(data)
+	}
+	return b.String()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
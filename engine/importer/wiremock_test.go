@@ -0,0 +1,153 @@
+package importer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mockingio/mockingio/engine/importer"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestFromWireMockFiles(t *testing.T) {
+	mok, report, err := FromWireMockFiles("fixtures/wiremock")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Imported from WireMock", mok.Name)
+	require.Len(t, mok.Routes, 9)
+
+	products := mok.Routes[0]
+	assert.Equal(t, "GET", products.Method)
+	assert.Equal(t, "/products", products.Path)
+	require.Len(t, products.Responses, 2)
+
+	// the mapping with the highest priority comes first
+	search := products.Responses[0]
+	assert.Equal(t, `{"id":"1","name":"book"}`, search.Body)
+	assert.Equal(t, []mock.Rule{
+		{Target: mock.QueryString, Modifier: "q", Operator: mock.EqualIgnoreCase, Value: "Book"},
+	}, search.Rules)
+
+	list := products.Responses[1]
+	abs, _ := filepath.Abs("fixtures/wiremock/__files/products.json")
+	assert.Equal(t, abs, list.FilePath)
+	assert.Equal(t, mock.Delay{Min: 20, Max: 20}, list.Delay)
+	assert.Equal(t, "application/json", list.Headers["Content-Type"])
+	assert.Empty(t, list.Rules)
+
+	create := mok.Routes[1]
+	assert.Equal(t, "POST", create.Method)
+	assert.Equal(t, "/products", create.Path)
+	assert.Equal(t, mock.Delay{Min: 60, Max: 60}, create.Responses[0].Delay)
+	assert.Equal(t, mock.And, create.Responses[0].RuleAggregation)
+	assert.Equal(t, []mock.Rule{
+		{Target: mock.QueryString, Modifier: "draft", Operator: mock.Equal, Value: "true"},
+		{Target: mock.Header, Modifier: "Authorization", Operator: mock.Regex, Value: "^(?:Bearer .+)$"},
+		{Target: mock.Header, Modifier: "X-Trace", Operator: mock.Absent},
+		{Target: mock.Body, Operator: mock.JSONContains, Value: `{"name":"book"}`},
+		{Target: mock.Body, Modifier: ".name", Operator: mock.Exists},
+		{Target: mock.Body, Modifier: ".tags[0]", Operator: mock.Equal, Value: "new"},
+	}, create.Responses[0].Rules)
+
+	product := mok.Routes[2]
+	assert.Equal(t, "/products/*", product.Path)
+	assert.Equal(t, "a, b", product.Responses[0].Headers["X-Tags"])
+
+	// the ANY method is translated to a route per method
+	for _, route := range mok.Routes[3:9] {
+		assert.Equal(t, "/pay", route.Path)
+		response := route.Responses[0]
+		assert.Equal(t, "checkout", response.Scenario)
+		assert.Equal(t, "started", response.RequiredState)
+		assert.Equal(t, "paid", response.NewState)
+		assert.Equal(t, &mock.Fault{Type: mock.FaultConnectionReset}, response.Fault)
+	}
+
+	assert.Equal(t, []ReportItem{
+		{Source: `mapping "get product"`, Message: "url pattern /products/[0-9]+ is approximated as /products/*"},
+		{Source: `mapping "create product"`, Message: "delay of 2000ms is capped to 60ms"},
+		{Source: `mapping "create product"`, Message: "response transformers response-template are not translated"},
+		{Source: `mapping "create product"`, Message: "JSON path $.items[?(@.price > 10)] is not translated"},
+		{Source: `mapping "proxy everything"`, Message: "proxy responses are not supported, the mapping is skipped"},
+	}, report.Items)
+}
+
+func TestFromWireMock(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapping  string
+		path     string
+		expected []mock.Rule
+	}{
+		{
+			"url with query string",
+			`{"request": {"method": "GET", "url": "/search?q=book"}, "response": {}}`,
+			"/search",
+			[]mock.Rule{{Target: mock.QueryString, Modifier: "q", Operator: mock.Equal, Value: "book"}},
+		},
+		{
+			"url path pattern",
+			`{"request": {"method": "GET", "urlPathPattern": "^/files/.*/raw$"}, "response": {}}`,
+			"/files/*/raw",
+			nil,
+		},
+		{
+			"literal url pattern",
+			`{"request": {"method": "GET", "urlPattern": "/api/v1\\.0/health"}, "response": {}}`,
+			"/api/v1.0/health",
+			nil,
+		},
+		{
+			"cookie and body matchers",
+			`{"request": {"method": "POST", "urlPath": "/login", "cookies": {"session": {"contains": "abc"}}, "bodyPatterns": [{"equalTo": "user=joe"}, {"matches": "user=.*"}]}, "response": {}}`,
+			"/login",
+			[]mock.Rule{
+				{Target: mock.Cookie, Modifier: "session", Operator: mock.Contains, Value: "abc"},
+				{Target: mock.Body, Operator: mock.Equal, Value: "user=joe"},
+				{Target: mock.Body, Operator: mock.Regex, Value: "^(?:user=.*)$"},
+			},
+		},
+		{
+			"basic auth",
+			`{"request": {"method": "GET", "urlPath": "/me", "basicAuthCredentials": {"username": "joe", "password": "secret"}}, "response": {}}`,
+			"/me",
+			[]mock.Rule{{Target: mock.Header, Modifier: "Authorization", Operator: mock.Equal, Value: "Basic am9lOnNlY3JldA=="}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mok, _, err := FromWireMock([]byte(tt.mapping))
+			require.NoError(t, err)
+			require.Len(t, mok.Routes, 1)
+			assert.Equal(t, tt.path, mok.Routes[0].Path)
+			assert.Equal(t, 200, mok.Routes[0].Responses[0].Status)
+			assert.Equal(t, tt.expected, mok.Routes[0].Responses[0].Rules)
+		})
+	}
+}
+
+func TestFromWireMock_SamePriority(t *testing.T) {
+	mok, _, err := FromWireMock([]byte(`{"mappings": [
+		{"request": {"method": "GET", "urlPath": "/hello", "headers": {"X-Name": {"equalTo": "joe"}}}, "response": {"body": "first"}},
+		{"request": {"method": "GET", "urlPath": "/hello"}, "response": {"body": "second"}}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, mok.Routes, 1)
+
+	// WireMock serves the most recently added mapping
+	responses := mok.Routes[0].Responses
+	require.Len(t, responses, 2)
+	assert.Equal(t, "second", responses[0].Body)
+	assert.Equal(t, "first", responses[1].Body)
+}
+
+func TestFromWireMock_Error(t *testing.T) {
+	_, _, err := FromWireMock([]byte("not json"))
+	assert.Error(t, err)
+
+	_, _, err = FromWireMockFiles("fixtures/not-found.json")
+	assert.Error(t, err)
+}
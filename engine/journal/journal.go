@@ -0,0 +1,90 @@
+package journal
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Entry is a request received by the engine. URL is the request URI sent by the client,
+// Path is the path without the path prefix of the mock.
+type Entry struct {
+	ID         string      `json:"id"`
+	MockID     string      `json:"mock_id"`
+	SessionID  string      `json:"session_id"`
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Host       string      `json:"host,omitempty"`
+	Path       string      `json:"path"`
+	Proto      string      `json:"proto,omitempty"`
+	Scheme     string      `json:"scheme,omitempty"`
+	RemoteAddr string      `json:"remote_addr,omitempty"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+	RouteID    string      `json:"route_id,omitempty"`
+	ResponseID string      `json:"response_id,omitempty"`
+	Matched    bool        `json:"matched"`
+	Status     int         `json:"status"`
+	Timestamp  time.Time   `json:"timestamp"`
+	// Latency is the time taken to serve the request, in milliseconds
+	Latency int64 `json:"latency"`
+	// ResponseHeaders and ResponseBody are the response served, the request and response bodies are truncated to 64KB
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    string      `json:"response_body,omitempty"`
+}
+
+// Filter selects journal entries, empty fields match everything
+type Filter struct {
+	SessionID  string
+	Method     string
+	Path       string
+	RouteID    string
+	ResponseID string
+	Matched    *bool
+	// Limit returns only the latest entries if greater than zero
+	Limit int
+}
+
+func (f Filter) Match(entry *Entry) bool {
+	if f.SessionID != "" && f.SessionID != entry.SessionID {
+		return false
+	}
+
+	if f.Method != "" && !strings.EqualFold(f.Method, entry.Method) {
+		return false
+	}
+
+	if f.Path != "" && f.Path != entry.Path {
+		return false
+	}
+
+	if f.RouteID != "" && f.RouteID != entry.RouteID {
+		return false
+	}
+
+	if f.ResponseID != "" && f.ResponseID != entry.ResponseID {
+		return false
+	}
+
+	if f.Matched != nil && *f.Matched != entry.Matched {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the entries matching the filter, in the order they were received
+func (f Filter) Apply(entries []*Entry) []*Entry {
+	result := []*Entry{}
+	for _, entry := range entries {
+		if f.Match(entry) {
+			result = append(result, entry)
+		}
+	}
+
+	if f.Limit > 0 && len(result) > f.Limit {
+		result = result[len(result)-f.Limit:]
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package journal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/journal"
+)
+
+func TestFilter_Apply(t *testing.T) {
+	matched := true
+	entries := []*Entry{
+		{ID: "1", SessionID: "s1", Method: "GET", Path: "/hello", RouteID: "r1", ResponseID: "res1", Matched: true},
+		{ID: "2", SessionID: "s1", Method: "POST", Path: "/hello", RouteID: "r1", ResponseID: "res2", Matched: true},
+		{ID: "3", SessionID: "s2", Method: "GET", Path: "/world"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{"no filter", Filter{}, []string{"1", "2", "3"}},
+		{"session", Filter{SessionID: "s2"}, []string{"3"}},
+		{"method, case insensitive", Filter{Method: "get"}, []string{"1", "3"}},
+		{"path", Filter{Path: "/hello"}, []string{"1", "2"}},
+		{"route", Filter{RouteID: "r1"}, []string{"1", "2"}},
+		{"response", Filter{ResponseID: "res2"}, []string{"2"}},
+		{"matched", Filter{Matched: &matched}, []string{"1", "2"}},
+		{"limit keeps the latest", Filter{Limit: 2}, []string{"2", "3"}},
+		{"no result", Filter{Path: "/none"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []string{}
+			for _, entry := range tt.filter.Apply(entries) {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+)
+
+// ReadBody reads the request body and restores it, so it can be read again by the next rule.
+func ReadBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	value, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read request body")
+	}
+	_ = req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(value))
+
+	return value, nil
+}
+
+// QueryJSON runs the gojq query against the JSON data and returns the first result.
+// Non string results are encoded as JSON.
+func QueryJSON(data []byte, query string) (string, error) {
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+
+	parsed, err := gojq.Parse(query)
+	if err != nil {
+		return "", nil
+	}
+
+	iter := parsed.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+
+		if v == nil {
+			return "", nil
+		}
+
+		if err, ok := v.(error); ok {
+			return "", errors.Wrapf(err, "unable to parse json query: %v", query)
+		}
+
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal json query result")
+		}
+		return string(encoded), nil
+	}
+	return "", nil
+}
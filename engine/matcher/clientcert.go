@@ -0,0 +1,65 @@
+package matcher
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+
+	"github.com/mockingio/mockingio/engine/database"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+// clientCert returns the certificate sent by the client with mutual TLS, nil if there is none
+func clientCert(req Context) *x509.Certificate {
+	if req.HTTPRequest.TLS == nil || len(req.HTTPRequest.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return req.HTTPRequest.TLS.PeerCertificates[0]
+}
+
+func getClientCertCN(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	cert := clientCert(req)
+	if cert == nil {
+		return "", nil
+	}
+	return cert.Subject.CommonName, nil
+}
+
+func getClientCertSAN(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	cert := clientCert(req)
+	if cert == nil {
+		return "", nil
+	}
+
+	var names []string
+	if modifier == "" || modifier == "dns" {
+		names = append(names, cert.DNSNames...)
+	}
+	if modifier == "" || modifier == "email" {
+		names = append(names, cert.EmailAddresses...)
+	}
+	if modifier == "" || modifier == "ip" {
+		for _, ip := range cert.IPAddresses {
+			names = append(names, ip.String())
+		}
+	}
+	if modifier == "" || modifier == "uri" {
+		for _, uri := range cert.URIs {
+			names = append(names, uri.String())
+		}
+	}
+
+	return strings.Join(names, ","), nil
+}
+
+func getClientCertFingerprint(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	cert := clientCert(req)
+	if cert == nil {
+		return "", nil
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:]), nil
+}
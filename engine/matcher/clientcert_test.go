@@ -0,0 +1,81 @@
+package matcher_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestRuleMatcher_ClientCert(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	spiffe, _ := url.Parse("spiffe://example.com/billing")
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "billing"},
+		DNSNames:       []string{"billing.example.com", "billing.internal"},
+		EmailAddresses: []string{"billing@example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{spiffe},
+		NotBefore:      time.Now(),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	sum := sha256.Sum256(der)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		rule    cfg.Rule
+		cert    bool
+		matched bool
+	}{
+		{"common name", cfg.Rule{Target: cfg.ClientCertCN, Operator: cfg.Equal, Value: "billing"}, true, true},
+		{"other common name", cfg.Rule{Target: cfg.ClientCertCN, Operator: cfg.Equal, Value: "shipping"}, true, false},
+		{"no client cert", cfg.Rule{Target: cfg.ClientCertCN, Operator: cfg.Equal, Value: "billing"}, false, false},
+		{"all SANs", cfg.Rule{Target: cfg.ClientCertSAN, Operator: cfg.Equal, Value: "billing.example.com,billing.internal,billing@example.com,10.0.0.1,spiffe://example.com/billing"}, true, true},
+		{"DNS SAN", cfg.Rule{Target: cfg.ClientCertSAN, Modifier: "dns", Operator: cfg.Regex, Value: `(^|,)billing\.internal(,|$)`}, true, true},
+		{"email SAN", cfg.Rule{Target: cfg.ClientCertSAN, Modifier: "email", Operator: cfg.Equal, Value: "billing@example.com"}, true, true},
+		{"IP SAN", cfg.Rule{Target: cfg.ClientCertSAN, Modifier: "ip", Operator: cfg.Equal, Value: "10.0.0.1"}, true, true},
+		{"URI SAN", cfg.Rule{Target: cfg.ClientCertSAN, Modifier: "uri", Operator: cfg.Equal, Value: "spiffe://example.com/billing"}, true, true},
+		{"fingerprint", cfg.Rule{Target: cfg.ClientCertFingerprint, Operator: cfg.Equal, Value: fingerprint}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cert {
+				req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+			}
+
+			rule := tt.rule
+			matched, err := matcher.NewRuleMatcher(&cfg.Mock{}, &cfg.Route{}, &rule, matcher.Context{
+				HTTPRequest: req,
+			}, nil).Match()
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 type Context struct {
 	HTTPRequest *http.Request
 	SessionID   string
+	// Message is the WebSocket message received from the client, if any
+	Message string
 }
 
 func (r Context) CountID() string {
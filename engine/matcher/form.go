@@ -0,0 +1,114 @@
+package matcher
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// FormPart is a part of a multipart/form-data body, a form field or a file
+type FormPart struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// ReadForm returns the fields of an application/x-www-form-urlencoded body, or the parts of a multipart/form-data body.
+// Other bodies have no field.
+func ReadForm(req *http.Request) ([]FormPart, error) {
+	body, err := ReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			// a body which isn't a form doesn't match, it isn't an error
+			return nil, nil
+		}
+
+		var parts []FormPart
+		for name, fieldValues := range values {
+			for _, value := range fieldValues {
+				parts = append(parts, FormPart{Name: name, Content: []byte(value)})
+			}
+		}
+		return parts, nil
+	case "multipart/form-data":
+		return readMultipart(body, params["boundary"])
+	default:
+		return nil, nil
+	}
+}
+
+func readMultipart(body []byte, boundary string) ([]FormPart, error) {
+	if boundary == "" {
+		return nil, nil
+	}
+
+	var parts []FormPart
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			// a malformed body only matches its first parts
+			return parts, nil
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, errors.Wrap(err, "read multipart part")
+		}
+
+		parts = append(parts, FormPart{
+			Name:        part.FormName(),
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     content,
+		})
+	}
+}
+
+// formPart returns the first part with the name, nil if there is none
+func formPart(req *http.Request, name string) (*FormPart, error) {
+	parts, err := ReadForm(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, part := range parts {
+		if part.Name == name {
+			return &part, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// formValue returns the value of the first form field with the name, files aren't form fields
+func formValue(req *http.Request, name string) (string, error) {
+	parts, err := ReadForm(req)
+	if err != nil {
+		return "", err
+	}
+
+	for _, part := range parts {
+		if part.Name == name && part.Filename == "" {
+			return string(part.Content), nil
+		}
+	}
+
+	return "", nil
+}
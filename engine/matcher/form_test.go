@@ -0,0 +1,115 @@
+package matcher_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestReadForm(t *testing.T) {
+	t.Run("url encoded", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=joe&tags=a&tags=b"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+		parts, err := matcher.ReadForm(req)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []matcher.FormPart{
+			{Name: "name", Content: []byte("joe")},
+			{Name: "tags", Content: []byte("a")},
+			{Name: "tags", Content: []byte("b")},
+		}, parts)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		parts, err := matcher.ReadForm(newMultipartRequest(t))
+		require.NoError(t, err)
+		assert.Equal(t, []matcher.FormPart{
+			{Name: "description", Content: []byte("my avatar")},
+			{Name: "avatar", Filename: "avatar.png", ContentType: "image/png", Content: []byte("PNG...")},
+		}, parts)
+	})
+
+	t.Run("body is read again", func(t *testing.T) {
+		req := newMultipartRequest(t)
+		_, err := matcher.ReadForm(req)
+		require.NoError(t, err)
+
+		parts, err := matcher.ReadForm(req)
+		require.NoError(t, err)
+		assert.Len(t, parts, 2)
+	})
+
+	t.Run("not a form", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "joe"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		parts, err := matcher.ReadForm(req)
+		require.NoError(t, err)
+		assert.Empty(t, parts)
+	})
+}
+
+func TestRuleMatcher_Form(t *testing.T) {
+	newFormRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=joe&age=20"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		rule    cfg.Rule
+		matched bool
+	}{
+		{"url encoded field", newFormRequest, cfg.Rule{Target: cfg.FormField, Modifier: "name", Operator: cfg.Equal, Value: "joe"}, true},
+		{"url encoded field, other value", newFormRequest, cfg.Rule{Target: cfg.FormField, Modifier: "name", Operator: cfg.Equal, Value: "jane"}, false},
+		{"url encoded number field", newFormRequest, cfg.Rule{Target: cfg.FormField, Modifier: "age", Operator: cfg.GreaterThanOrEqual, Value: "18"}, true},
+		{"multipart field", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.FormField, Modifier: "description", Operator: cfg.Contains, Value: "avatar"}, true},
+		{"multipart file isn't a field", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.FormField, Modifier: "avatar", Operator: cfg.Absent}, true},
+		{"multipart part exists", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.MultipartContent, Modifier: "avatar", Operator: cfg.Exists}, true},
+		{"multipart missing part", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.MultipartContent, Modifier: "document", Operator: cfg.Exists}, false},
+		{"multipart content", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.MultipartContent, Modifier: "avatar", Operator: cfg.StartsWith, Value: "PNG"}, true},
+		{"multipart filename", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.MultipartFilename, Modifier: "avatar", Operator: cfg.EndsWith, Value: ".png"}, true},
+		{"multipart content type", func() *http.Request { return newMultipartRequest(t) }, cfg.Rule{Target: cfg.MultipartContentType, Modifier: "avatar", Operator: cfg.Equal, Value: "image/png"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			matched, err := matcher.NewRuleMatcher(&cfg.Mock{}, &cfg.Route{}, &rule, matcher.Context{
+				HTTPRequest: tt.request(),
+			}, nil).Match()
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
+
+func newMultipartRequest(t *testing.T) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("description", "my avatar"))
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar.png"`)
+	header.Set("Content-Type", "image/png")
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("PNG..."))
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
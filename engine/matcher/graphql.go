@@ -0,0 +1,75 @@
+package matcher
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// operationNamePattern finds the name of the first named operation of a GraphQL document
+var operationNamePattern = regexp.MustCompile(`\b(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// GraphQLRequest is a GraphQL operation sent over HTTP
+type GraphQLRequest struct {
+	Query         string          `json:"query"`
+	OperationName string          `json:"operationName"`
+	Variables     json.RawMessage `json:"variables"`
+}
+
+// ReadGraphQL reads the GraphQL operation from the query string of GET requests,
+// and from the JSON or application/graphql body of other requests.
+// Requests which aren't GraphQL operations return an empty operation.
+func ReadGraphQL(req *http.Request) (*GraphQLRequest, error) {
+	operation := &GraphQLRequest{}
+
+	if req.Method == http.MethodGet {
+		query := req.URL.Query()
+		operation.Query = query.Get("query")
+		operation.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			operation.Variables = json.RawMessage(variables)
+		}
+	} else {
+		body, err := ReadBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if mediaType == "application/graphql" {
+			operation.Query = string(body)
+		} else if len(body) > 0 {
+			// a body which isn't a GraphQL operation doesn't match, it isn't an error
+			_ = json.Unmarshal(body, operation)
+		}
+	}
+
+	if operation.OperationName == "" {
+		if match := operationNamePattern.FindStringSubmatch(operation.Query); match != nil {
+			operation.OperationName = match[1]
+		}
+	}
+
+	return operation, nil
+}
+
+// Variable returns the value of the gojq query against the variables, or all the variables as JSON if the query is empty
+func (o GraphQLRequest) Variable(query string) (string, error) {
+	if len(o.Variables) == 0 || string(o.Variables) == "null" {
+		return "", nil
+	}
+
+	if query == "" {
+		return string(o.Variables), nil
+	}
+
+	value, err := QueryJSON(o.Variables, query)
+	if err != nil {
+		return "", errors.Wrap(err, "query GraphQL variables")
+	}
+
+	return value, nil
+}
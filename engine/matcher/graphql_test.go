@@ -0,0 +1,93 @@
+package matcher_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+const graphQLQuery = `query GetUser($id: ID!) { user(id: $id) { name } }`
+
+func TestReadGraphQL(t *testing.T) {
+	getRequest := httptest.NewRequest(http.MethodGet, "/graphql?"+url.Values{
+		"query":     {graphQLQuery},
+		"variables": {`{"id": "1"}`},
+	}.Encode(), nil)
+
+	postRequest := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(
+		`{"query": "query GetUser($id: ID!) { user(id: $id) { name } }", "operationName": "GetUser", "variables": {"id": "1"}}`,
+	))
+	postRequest.Header.Set("Content-Type", "application/json")
+
+	rawRequest := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(graphQLQuery))
+	rawRequest.Header.Set("Content-Type", "application/graphql; charset=utf-8")
+
+	tests := []struct {
+		name          string
+		request       *http.Request
+		operationName string
+		variables     string
+	}{
+		{"GET", getRequest, "GetUser", `{"id": "1"}`},
+		{"POST JSON", postRequest, "GetUser", `{"id": "1"}`},
+		{"POST application/graphql", rawRequest, "GetUser", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation, err := matcher.ReadGraphQL(tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, graphQLQuery, operation.Query)
+			assert.Equal(t, tt.operationName, operation.OperationName)
+			assert.Equal(t, tt.variables, string(operation.Variables))
+		})
+	}
+
+	t.Run("not a GraphQL request", func(t *testing.T) {
+		operation, err := matcher.ReadGraphQL(httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("hello")))
+		require.NoError(t, err)
+		assert.Empty(t, operation.Query)
+		assert.Empty(t, operation.OperationName)
+	})
+}
+
+func TestRuleMatcher_GraphQL(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(
+			`{"query": "mutation CreateUser($input: UserInput!) { createUser(input: $input) { id } }", "variables": {"input": {"name": "joe", "age": 20}}}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	tests := []struct {
+		rule    cfg.Rule
+		matched bool
+	}{
+		{cfg.Rule{Target: cfg.GraphQLOperationName, Operator: cfg.Equal, Value: "CreateUser"}, true},
+		{cfg.Rule{Target: cfg.GraphQLOperationName, Operator: cfg.Equal, Value: "GetUser"}, false},
+		{cfg.Rule{Target: cfg.GraphQLQuery, Operator: cfg.Regex, Value: `createUser\(`}, true},
+		{cfg.Rule{Target: cfg.GraphQLVariable, Modifier: ".input.name", Operator: cfg.Equal, Value: "joe"}, true},
+		{cfg.Rule{Target: cfg.GraphQLVariable, Modifier: ".input.age", Operator: cfg.Equal, Value: "20"}, true},
+		{cfg.Rule{Target: cfg.GraphQLVariable, Modifier: ".input.name", Operator: cfg.Equal, Value: "jane"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.rule.Target)+" "+tt.rule.Value, func(t *testing.T) {
+			rule := tt.rule
+			matched, err := matcher.NewRuleMatcher(&cfg.Mock{}, &cfg.Route{}, &rule, matcher.Context{
+				HTTPRequest: newRequest(),
+			}, nil).Match()
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/invopop/yaml"
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+// isJSON returns true for JSON content types, including charset parameters and +json suffixes,
+// e.g. application/json; charset=utf-8 or application/vnd.api+json
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// matchJSONContains returns true if the actual JSON is a superset of the expected JSON.
+// Arrays must contain the expected elements in the same order, or in any order if ignoreArrayOrder is set.
+func matchJSONContains(actual, expected string, ignoreArrayOrder bool) bool {
+	if !json.Valid([]byte(expected)) {
+		return false
+	}
+
+	return jsonContains(parseJSONValue(actual), parseJSONValue(expected), ignoreArrayOrder)
+}
+
+func jsonContains(actual, expected interface{}, ignoreArrayOrder bool) bool {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range expected {
+			actualValue, ok := actual[key]
+			if !ok || !jsonContains(actualValue, value, ignoreArrayOrder) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok {
+			return false
+		}
+		if ignoreArrayOrder {
+			return containsElements(actual, expected, make([]bool, len(actual)))
+		}
+		// the expected elements are found in order, other elements can be in between
+		i := 0
+		for _, element := range actual {
+			if i < len(expected) && jsonContains(element, expected[i], false) {
+				i++
+			}
+		}
+		return i == len(expected)
+	case json.Number:
+		actual, ok := actual.(json.Number)
+		if !ok {
+			return false
+		}
+		actualFloat, err1 := actual.Float64()
+		expectedFloat, err2 := expected.Float64()
+		return err1 == nil && err2 == nil && actualFloat == expectedFloat
+	default:
+		return actual == expected
+	}
+}
+
+// containsElements returns true if each expected element is contained by a different actual element
+func containsElements(actual, expected []interface{}, used []bool) bool {
+	if len(expected) == 0 {
+		return true
+	}
+
+	for i, element := range actual {
+		if used[i] || !jsonContains(element, expected[0], true) {
+			continue
+		}
+
+		used[i] = true
+		if containsElements(actual, expected[1:], used) {
+			return true
+		}
+		used[i] = false
+	}
+
+	return false
+}
+
+// schemas are the compiled JSON schemas, by inline schema or by schema file
+var schemas = struct {
+	sync.Mutex
+	compiled map[string]compiledSchema
+}{compiled: map[string]compiledSchema{}}
+
+type compiledSchema struct {
+	schema *jsonschema.Schema
+	// modTime is the modification time of the schema file, the schema is compiled again when the file changes
+	modTime time.Time
+}
+
+// matchJSONSchema validates the value against the schema of the rule, which is inline JSON
+// or a JSON or YAML schema file, relative to the mock file.
+func matchJSONSchema(mok *cfg.Mock, value, schema string) (bool, error) {
+	compiled, err := loadJSONSchema(mok, schema)
+	if err != nil {
+		return false, err
+	}
+
+	return compiled.Validate(parseJSONValue(value)) == nil, nil
+}
+
+// loadJSONSchema returns the compiled schema, from the cache unless the schema file changed
+func loadJSONSchema(mok *cfg.Mock, schema string) (*jsonschema.Schema, error) {
+	key := schema
+	var modTime time.Time
+	inline := strings.HasPrefix(strings.TrimSpace(schema), "{")
+	if !inline {
+		key = mok.ResolvePath(schema)
+		info, err := os.Stat(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read JSON schema file: %v", key)
+		}
+		modTime = info.ModTime()
+	}
+
+	schemas.Lock()
+	cached, ok := schemas.compiled[key]
+	schemas.Unlock()
+	if ok && cached.modTime.Equal(modTime) {
+		return cached.schema, nil
+	}
+
+	document := []byte(schema)
+	if !inline {
+		content, err := os.ReadFile(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read JSON schema file: %v", key)
+		}
+		if document, err = yaml.YAMLToJSON(content); err != nil {
+			return nil, errors.Wrapf(err, "parse JSON schema file: %v", key)
+		}
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", bytes.NewReader(document)); err != nil {
+		return nil, errors.Wrap(err, "load JSON schema")
+	}
+
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "compile JSON schema")
+	}
+
+	schemas.Lock()
+	schemas.compiled[key] = compiledSchema{schema: compiled, modTime: modTime}
+	schemas.Unlock()
+
+	return compiled, nil
+}
+
+// parseJSONValue parses the JSON value, values which aren't JSON are strings, e.g. a string selected by a modifier
+func parseJSONValue(value string) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+		return value
+	}
+
+	return parsed
+}
@@ -0,0 +1,95 @@
+package matcher_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestRuleMatcher_JSON(t *testing.T) {
+	const body = `{"name": "joe", "email": "joe@example.com", "age": 20, "tags": ["a", "b", "c"], "roles": [{"name": "admin", "scope": "all"}, {"name": "user"}]}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		rule        cfg.Rule
+		matched     bool
+		error       bool
+	}{
+		{"equal, content type with charset", "application/json; charset=utf-8", `{"b": 1, "a": 2}`, cfg.Rule{Target: cfg.Body, Operator: cfg.Equal, Value: `{"a": 2, "b": 1}`}, true, false},
+		{"equal, +json content type", "application/vnd.api+json", `{"b": 1, "a": 2}`, cfg.Rule{Target: cfg.Body, Operator: cfg.Equal, Value: `{"a": 2, "b": 1}`}, true, false},
+		{"equal, not JSON content type", "text/plain", `{"b": 1, "a": 2}`, cfg.Rule{Target: cfg.Body, Operator: cfg.Equal, Value: `{"a": 2, "b": 1}`}, false, false},
+		{"equal, string selected by a modifier", "application/json", body, cfg.Rule{Target: cfg.Body, Modifier: ".name", Operator: cfg.Equal, Value: "joe"}, true, false},
+
+		{"contains, subset of fields", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"name": "joe", "age": 20.0}`}, true, false},
+		{"contains, other value", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"name": "jane"}`}, false, false},
+		{"contains, missing field", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"phone": "123"}`}, false, false},
+		{"contains, nested objects in arrays", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"roles": [{"name": "admin"}]}`}, true, false},
+		{"contains, array elements in order", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"tags": ["a", "c"]}`}, true, false},
+		{"contains, array elements out of order", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"tags": ["c", "a"]}`}, false, false},
+		{"contains, array elements ignoring order", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"tags": ["c", "a"]}`, IgnoreArrayOrder: true}, true, false},
+		{"contains, array element matched once", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"tags": ["a", "a"]}`, IgnoreArrayOrder: true}, false, false},
+		{"contains, with a modifier", "application/json", body, cfg.Rule{Target: cfg.Body, Modifier: ".roles[0]", Operator: cfg.JSONContains, Value: `{"scope": "all"}`}, true, false},
+		{"contains, not JSON body", "text/plain", "hello", cfg.Rule{Target: cfg.Body, Operator: cfg.JSONContains, Value: `{"name": "joe"}`}, false, false},
+
+		{"inline schema", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: `{"type": "object", "required": ["name"]}`}, true, false},
+		{"inline schema, invalid body", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: `{"type": "object", "required": ["phone"]}`}, false, false},
+		{"inline schema, with a modifier", "application/json", body, cfg.Rule{Target: cfg.Body, Modifier: ".age", Operator: cfg.JSONSchema, Value: `{"type": "integer", "maximum": 18}`}, false, false},
+		{"schema file", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: "user.schema.yaml"}, true, false},
+		{"schema file, invalid body", "application/json", `{"name": "joe", "email": "joe"}`, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: "user.schema.yaml"}, false, false},
+		{"missing schema file", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: "missing.json"}, false, true},
+		{"invalid schema", "application/json", body, cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: `{"type": 1}`}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			rule := tt.rule
+			matched, err := matcher.NewRuleMatcher(&cfg.Mock{FilePath: "fixtures/mock.yml"}, &cfg.Route{}, &rule, matcher.Context{
+				HTTPRequest: req,
+			}, nil).Match()
+			if tt.error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
+
+func TestRuleMatcher_JSONSchemaFileChange(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "schema.json")
+	mok := &cfg.Mock{FilePath: filepath.Join(dir, "mock.yml")}
+	rule := cfg.Rule{Target: cfg.Body, Operator: cfg.JSONSchema, Value: "schema.json"}
+
+	match := func() bool {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name": "joe"}`))
+		matched, err := matcher.NewRuleMatcher(mok, &cfg.Route{}, &rule, matcher.Context{HTTPRequest: req}, nil).Match()
+		require.NoError(t, err)
+		return matched
+	}
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"required": ["name"]}`), 0600))
+	assert.True(t, match())
+	assert.True(t, match())
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"required": ["phone"]}`), 0600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(file, later, later))
+	assert.False(t, match())
+}
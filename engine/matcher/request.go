@@ -0,0 +1,69 @@
+package matcher
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/mockingio/mockingio/engine/database"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+func getMethod(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	return req.HTTPRequest.Method, nil
+}
+
+// getPath returns the full path of the request. The URL path doesn't have the path prefix of the mock,
+// the request URI is left untouched.
+func getPath(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	if uri := req.HTTPRequest.RequestURI; uri != "" {
+		if u, err := url.ParseRequestURI(uri); err == nil {
+			return u.Path, nil
+		}
+	}
+	return req.HTTPRequest.URL.Path, nil
+}
+
+func getRawQuery(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	return req.HTTPRequest.URL.RawQuery, nil
+}
+
+func getHost(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	host := req.HTTPRequest.Host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		return hostname, nil
+	}
+	return host, nil
+}
+
+func getScheme(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	if req.HTTPRequest.TLS != nil {
+		return "https", nil
+	}
+	return "http", nil
+}
+
+func getRemoteIP(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	addr := req.HTTPRequest.RemoteAddr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host, nil
+	}
+	return addr, nil
+}
+
+func getProtocol(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	return req.HTTPRequest.Proto, nil
+}
+
+func getContentLength(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	if req.HTTPRequest.ContentLength >= 0 {
+		return strconv.FormatInt(req.HTTPRequest.ContentLength, 10), nil
+	}
+
+	// the length of chunked bodies is unknown until they are read
+	body, err := ReadBody(req.HTTPRequest)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(len(body)), nil
+}
@@ -0,0 +1,70 @@
+package matcher_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	cfg "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestRuleMatcher_Request(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "https://api.example.com:8443/users/42?verbose=true&page=2", strings.NewReader(`{"name": "joe"}`))
+		req.RemoteAddr = "10.1.2.3:52100"
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		rule    cfg.Rule
+		matched bool
+	}{
+		{"method", newRequest, cfg.Rule{Target: cfg.Method, Operator: cfg.In, Value: "POST,PUT"}, true},
+		{"method, other method", newRequest, cfg.Rule{Target: cfg.Method, Operator: cfg.Equal, Value: "GET"}, false},
+		{"path", newRequest, cfg.Rule{Target: cfg.Path, Operator: cfg.Equal, Value: "/users/42"}, true},
+		{"path prefix", newRequest, cfg.Rule{Target: cfg.Path, Operator: cfg.StartsWith, Value: "/users/"}, true},
+		{"raw query", newRequest, cfg.Rule{Target: cfg.RawQuery, Operator: cfg.Equal, Value: "verbose=true&page=2"}, true},
+		{"host without port", newRequest, cfg.Rule{Target: cfg.Host, Operator: cfg.Equal, Value: "api.example.com"}, true},
+		{"scheme", newRequest, cfg.Rule{Target: cfg.Scheme, Operator: cfg.Equal, Value: "https"}, true},
+		{"scheme without TLS", func() *http.Request {
+			req := newRequest()
+			req.TLS = nil
+			return req
+		}, cfg.Rule{Target: cfg.Scheme, Operator: cfg.Equal, Value: "http"}, true},
+		{"remote IP", newRequest, cfg.Rule{Target: cfg.RemoteIP, Operator: cfg.Equal, Value: "10.1.2.3"}, true},
+		{"remote IP in CIDR", newRequest, cfg.Rule{Target: cfg.RemoteIP, Operator: cfg.CIDR, Value: "192.168.0.0/16, 10.0.0.0/8"}, true},
+		{"remote IP not in CIDR", newRequest, cfg.Rule{Target: cfg.RemoteIP, Operator: cfg.CIDR, Value: "192.168.0.0/16,127.0.0.1"}, false},
+		{"remote IPv6 in CIDR", func() *http.Request {
+			req := newRequest()
+			req.RemoteAddr = "[::1]:52100"
+			return req
+		}, cfg.Rule{Target: cfg.RemoteIP, Operator: cfg.CIDR, Value: "::1/128"}, true},
+		{"protocol", newRequest, cfg.Rule{Target: cfg.Protocol, Operator: cfg.Equal, Value: "HTTP/1.1"}, true},
+		{"content length", newRequest, cfg.Rule{Target: cfg.ContentLength, Operator: cfg.GreaterThan, Value: "10"}, true},
+		{"content length of a chunked body", func() *http.Request {
+			req := newRequest()
+			req.ContentLength = -1
+			req.Body = io.NopCloser(strings.NewReader("hello"))
+			return req
+		}, cfg.Rule{Target: cfg.ContentLength, Operator: cfg.Equal, Value: "5"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			matched, err := matcher.NewRuleMatcher(&cfg.Mock{}, &cfg.Route{}, &rule, matcher.Context{
+				HTTPRequest: tt.request(),
+			}, nil).Match()
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/mockingio/mockingio/engine/database"
 	cfg "github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/scenario"
 )
 
 func NewResponseMatcher(
@@ -32,17 +33,44 @@ type ResponseMatcher struct {
 }
 
 func (r *ResponseMatcher) Match() (bool, error) {
-	if len(r.response.Rules) == 0 {
+	if r.response.Scenario != "" && r.response.RequiredState != "" {
+		state, err := scenario.GetState(r.req.HTTPRequest.Context(), r.db, r.mock.ID, r.req.SessionID, r.response.Scenario)
+		if err != nil {
+			return false, errors.Wrap(err, "matching scenario")
+		}
+
+		if state != r.response.RequiredState {
+			return false, nil
+		}
+	}
+
+	matched, err := MatchRules(r.mock, r.route, r.response.RuleAggregation, r.response.Rules, r.req, r.db)
+	if err != nil || !matched || r.response.Match == nil {
+		return matched, err
+	}
+
+	return MatchGroup(r.mock, r.route, r.response.Match, r.req, r.db)
+}
+
+// MatchRules matches the request against the rules, all of them with the and aggregation, one of them with or
+func MatchRules(
+	mok *cfg.Mock,
+	route *cfg.Route,
+	aggregation cfg.RuleAggregation,
+	rules []cfg.Rule,
+	req Context,
+	db database.EngineDB,
+) (bool, error) {
+	if len(rules) == 0 {
 		return true, nil
 	}
 
-	aggregation := r.response.RuleAggregation
 	if aggregation == "" {
 		aggregation = cfg.And
 	}
 
-	for _, rule := range r.response.Rules {
-		matched, err := NewRuleMatcher(r.mock, r.route, &rule, r.req, r.db).Match() // matcher. rule.Match(route, request)
+	for _, rule := range rules {
+		matched, err := NewRuleMatcher(mok, route, &rule, req, db).Match()
 		if err != nil {
 			return false, errors.Wrap(err, "matching rule")
 		}
@@ -63,3 +91,53 @@ func (r *ResponseMatcher) Match() (bool, error) {
 
 	return false, nil
 }
+
+// MatchGroup matches the request against a rule group, nested groups are matched recursively
+func MatchGroup(
+	mok *cfg.Mock,
+	route *cfg.Route,
+	group *cfg.RuleGroup,
+	req Context,
+	db database.EngineDB,
+) (bool, error) {
+	if group.IsRule() {
+		matched, err := NewRuleMatcher(mok, route, &group.Rule, req, db).Match()
+		if err != nil {
+			return false, errors.Wrap(err, "matching rule")
+		}
+		return matched, nil
+	}
+
+	for i := range group.All {
+		matched, err := MatchGroup(mok, route, &group.All[i], req, db)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if len(group.Any) > 0 {
+		anyMatched := false
+		for i := range group.Any {
+			matched, err := MatchGroup(mok, route, &group.Any[i], req, db)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				anyMatched = true
+				break
+			}
+		}
+		if !anyMatched {
+			return false, nil
+		}
+	}
+
+	for i := range group.None {
+		matched, err := MatchGroup(mok, route, &group.None[i], req, db)
+		if err != nil || matched {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
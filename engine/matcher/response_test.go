@@ -97,6 +97,67 @@ func TestResponseMatcher_Match(t *testing.T) {
 			},
 			isMatched: false,
 		},
+		{
+			name: "group (A and B) or C, A and B matched",
+			response: &cfg.Response{
+				Match: &cfg.RuleGroup{Any: []cfg.RuleGroup{
+					{All: []cfg.RuleGroup{authorizationRule("Bearer 123"), nameRule("Joe")}},
+					{Rule: cfg.Rule{Target: cfg.QueryString, Modifier: "debug", Value: "true", Operator: cfg.Equal}},
+				}},
+			},
+			isMatched: true,
+		},
+		{
+			name: "group (A and B) or C, only A matched",
+			response: &cfg.Response{
+				Match: &cfg.RuleGroup{Any: []cfg.RuleGroup{
+					{All: []cfg.RuleGroup{authorizationRule("Bearer 123"), nameRule("random name")}},
+					{Rule: cfg.Rule{Target: cfg.QueryString, Modifier: "debug", Value: "true", Operator: cfg.Equal}},
+				}},
+			},
+			isMatched: false,
+		},
+		{
+			name: "group not A, A matched",
+			response: &cfg.Response{
+				Match: &cfg.RuleGroup{None: []cfg.RuleGroup{authorizationRule("Bearer 123")}},
+			},
+			isMatched: false,
+		},
+		{
+			name: "group not A, A not matched",
+			response: &cfg.Response{
+				Match: &cfg.RuleGroup{None: []cfg.RuleGroup{authorizationRule("random token")}},
+			},
+			isMatched: true,
+		},
+		{
+			name: "group with all, any and none, all of them matched",
+			response: &cfg.Response{
+				Match: &cfg.RuleGroup{
+					All:  []cfg.RuleGroup{authorizationRule("Bearer 123")},
+					Any:  []cfg.RuleGroup{nameRule("random name"), nameRule("Joe")},
+					None: []cfg.RuleGroup{nameRule("random name")},
+				},
+			},
+			isMatched: true,
+		},
+		{
+			name: "rules and group, group not matched",
+			response: &cfg.Response{
+				Rules: []cfg.Rule{{Target: cfg.Header, Modifier: "Authorization", Value: "Bearer 123", Operator: cfg.Equal}},
+				Match: &cfg.RuleGroup{None: []cfg.RuleGroup{nameRule("Joe")}},
+			},
+			isMatched: false,
+		},
+		{
+			name: "rules and group, rules not matched",
+			response: &cfg.Response{
+				Rules: []cfg.Rule{{Target: cfg.Header, Modifier: "Authorization", Value: "random token", Operator: cfg.Equal}},
+				Match: &cfg.RuleGroup{All: []cfg.RuleGroup{nameRule("Joe")}},
+			},
+			isMatched: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -110,3 +171,11 @@ func TestResponseMatcher_Match(t *testing.T) {
 		})
 	}
 }
+
+func authorizationRule(value string) cfg.RuleGroup {
+	return cfg.RuleGroup{Rule: cfg.Rule{Target: cfg.Header, Modifier: "Authorization", Value: value, Operator: cfg.Equal}}
+}
+
+func nameRule(value string) cfg.RuleGroup {
+	return cfg.RuleGroup{Rule: cfg.Rule{Target: cfg.Body, Modifier: ".name", Value: value, Operator: cfg.Equal}}
+}
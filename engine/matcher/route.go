@@ -45,8 +45,7 @@ func (r *RouteMatcher) Match() (*cfg.Response, error) {
 		return nil, nil
 	}
 
-	wildcardPath := toWildcardPath(r.route.Path)
-	if !wildcard.Match(wildcardPath, httpRequest.URL.Path) {
+	if !MatchPath(r.route.Path, httpRequest.URL.Path) {
 		return nil, nil
 	}
 
@@ -127,6 +126,11 @@ func (r *RouteMatcher) findMatches() ([]*cfg.Response, error) {
 	return responses, nil
 }
 
+// MatchPath reports whether the request path matches the route path, route params and wildcards included
+func MatchPath(routePath, requestPath string) bool {
+	return wildcard.Match(toWildcardPath(routePath), requestPath)
+}
+
 func toWildcardPath(path string) string {
 	parts := strings.Split(path, "/")
 	for i, part := range parts {
@@ -137,3 +141,22 @@ func toWildcardPath(path string) string {
 
 	return strings.Join(parts, "/")
 }
+
+// RouteParams extracts the route params, e.g. /users/:id, from the request path
+func RouteParams(routePath, requestPath string) map[string]string {
+	params := map[string]string{}
+
+	templateParts := strings.Split(routePath, "/")
+	actualParts := strings.Split(requestPath, "/")
+	if len(templateParts) != len(actualParts) {
+		return params
+	}
+
+	for i, templatePart := range templateParts {
+		if p, ok := param(templatePart); ok {
+			params[p] = actualParts[i]
+		}
+	}
+
+	return params
+}
@@ -2,7 +2,10 @@ package matcher
 
 import (
 	"encoding/json"
+	"net"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -37,6 +40,10 @@ func (r *RuleMatcher) Match() (bool, error) {
 
 	rule := r.rule
 
+	if rule.IsNumeric() {
+		return matchNumber(value, rule)
+	}
+
 	switch rule.Operator {
 	case cfg.Regex:
 		matched, err := regexp.MatchString(rule.Value, value)
@@ -45,16 +52,51 @@ func (r *RuleMatcher) Match() (bool, error) {
 		}
 		return matched, nil
 	case cfg.Equal:
-		// special treatment for target is Body, with JSON. We'll need to compare json
-		if r.rule.Target == cfg.Body && r.req.HTTPRequest.Header.Get("Content-Type") == "application/json" {
-			return matchJSON(value, rule.Value), nil
+		return r.equal(value), nil
+	case cfg.NotEqual:
+		return !r.equal(value), nil
+	case cfg.EqualIgnoreCase:
+		return strings.EqualFold(value, rule.Value), nil
+	case cfg.Contains:
+		return strings.Contains(value, rule.Value), nil
+	case cfg.StartsWith:
+		return strings.HasPrefix(value, rule.Value), nil
+	case cfg.EndsWith:
+		return strings.HasSuffix(value, rule.Value), nil
+	case cfg.In:
+		for _, v := range rule.ValueList() {
+			if value == v {
+				return true, nil
+			}
 		}
-		return value == rule.Value, nil
+		return false, nil
+	case cfg.Exists:
+		return value != "", nil
+	case cfg.Absent:
+		return value == "", nil
+	case cfg.CIDR:
+		return matchCIDR(value, rule), nil
+	case cfg.JSONContains:
+		return matchJSONContains(value, rule.Value, rule.IgnoreArrayOrder), nil
+	case cfg.JSONSchema:
+		return matchJSONSchema(r.mock, value, rule.Value)
 	default:
 		return false, nil
 	}
 }
 
+func (r *RuleMatcher) equal(value string) bool {
+	if value == r.rule.Value {
+		return true
+	}
+
+	// special treatment for target is Body, with JSON. We'll need to compare json
+	if r.rule.Target == cfg.Body && isJSON(r.req.HTTPRequest.Header.Get("Content-Type")) {
+		return matchJSON(value, r.rule.Value)
+	}
+	return false
+}
+
 func (r *RuleMatcher) GetTargetValue() (string, error) {
 	if targetFn, ok := targets[r.rule.Target]; ok {
 		return targetFn(r.mock, r.route, r.rule.Modifier, r.req, r.db)
@@ -75,6 +117,55 @@ func param(p string) (string, bool) {
 	return "", false
 }
 
+// matchNumber compares the target value with the numbers of the rule, values which aren't numbers don't match
+func matchNumber(value string, rule *cfg.Rule) (bool, error) {
+	numbers, err := rule.Numbers()
+	if err != nil {
+		return false, errors.Wrap(err, "rule value")
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false, nil
+	}
+
+	switch rule.Operator {
+	case cfg.GreaterThan:
+		return number > numbers[0], nil
+	case cfg.GreaterThanOrEqual:
+		return number >= numbers[0], nil
+	case cfg.LessThan:
+		return number < numbers[0], nil
+	case cfg.LessThanOrEqual:
+		return number <= numbers[0], nil
+	default:
+		return number >= numbers[0] && number <= numbers[1], nil
+	}
+}
+
+// matchCIDR returns true if the value is an IP in one of the CIDR ranges or IPs of the rule
+func matchCIDR(value string, rule *cfg.Rule) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+
+	for _, v := range rule.ValueList() {
+		if _, network, err := net.ParseCIDR(v); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		if other := net.ParseIP(v); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func matchJSON(actual, expected string) bool {
 	var actualJSON, expectedJSON interface{}
 
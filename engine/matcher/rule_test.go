@@ -168,6 +168,70 @@ func TestRuleMatcher_GetTargetValue(t *testing.T) {
 	}
 }
 
+func TestRuleMatcher_Operators(t *testing.T) {
+	header := func(operator cfg.Operator, value string) cfg.Rule {
+		return cfg.Rule{Target: cfg.Header, Modifier: "Authorization", Operator: operator, Value: value}
+	}
+	postcode := func(operator cfg.Operator, value string) cfg.Rule {
+		return cfg.Rule{Target: cfg.Body, Modifier: ".address.postcode", Operator: operator, Value: value}
+	}
+
+	tests := []struct {
+		name    string
+		rule    cfg.Rule
+		matched bool
+	}{
+		{"not equal", header(cfg.NotEqual, "Bearer 456"), true},
+		{"not equal, same value", header(cfg.NotEqual, "Bearer 123"), false},
+		{"not equal, same JSON body", cfg.Rule{Target: cfg.Body, Operator: cfg.NotEqual, Value: `{"address": {"postcode": "2234", "street": "123 Road"}, "name": "joe"}`}, false},
+		{"equal ignore case", header(cfg.EqualIgnoreCase, "bearer 123"), true},
+		{"equal ignore case, other value", header(cfg.EqualIgnoreCase, "bearer 1234"), false},
+		{"contains", header(cfg.Contains, "arer 1"), true},
+		{"contains, not found", header(cfg.Contains, "Basic"), false},
+		{"starts with", header(cfg.StartsWith, "Bearer "), true},
+		{"starts with, other prefix", header(cfg.StartsWith, "Basic "), false},
+		{"ends with", header(cfg.EndsWith, "123"), true},
+		{"ends with, other suffix", header(cfg.EndsWith, "12"), false},
+		{"in", header(cfg.In, "Bearer 456, Bearer 123"), true},
+		{"in, not found", header(cfg.In, "Bearer 456,Bearer 789"), false},
+		{"in, values", cfg.Rule{Target: cfg.Header, Modifier: "Authorization", Operator: cfg.In, Values: []string{"Bearer 456", "Bearer 123"}}, true},
+		{"in, values are not split", cfg.Rule{Target: cfg.Header, Modifier: "Authorization", Operator: cfg.In, Values: []string{"Bearer 456, Bearer 123"}}, false},
+		{"exists", header(cfg.Exists, ""), true},
+		{"exists, missing header", cfg.Rule{Target: cfg.Header, Modifier: "X-Request-ID", Operator: cfg.Exists}, false},
+		{"absent", cfg.Rule{Target: cfg.Header, Modifier: "X-Request-ID", Operator: cfg.Absent}, true},
+		{"absent, header found", header(cfg.Absent, ""), false},
+		{"greater than", postcode(cfg.GreaterThan, "2000"), true},
+		{"greater than, equal", postcode(cfg.GreaterThan, "2234"), false},
+		{"greater than or equal", postcode(cfg.GreaterThanOrEqual, "2234"), true},
+		{"less than", postcode(cfg.LessThan, "2234.5"), true},
+		{"less than, greater", postcode(cfg.LessThan, "1000"), false},
+		{"less than or equal", postcode(cfg.LessThanOrEqual, "2234"), true},
+		{"between", postcode(cfg.Between, "2000, 3000"), true},
+		{"between, inclusive", postcode(cfg.Between, "2234,2234"), true},
+		{"between, out of range", postcode(cfg.Between, "1,10"), false},
+		{"number operator, not a number", header(cfg.GreaterThan, "1"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			matched, err := matcher.NewRuleMatcher(&cfg.Mock{}, &cfg.Route{}, &rule, matcher.Context{
+				HTTPRequest: newHTTPRequest(),
+			}, nil).Match()
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+
+	t.Run("invalid number", func(t *testing.T) {
+		rule := postcode(cfg.Between, "10")
+		_, err := matcher.NewRuleMatcher(&cfg.Mock{}, &cfg.Route{}, &rule, matcher.Context{
+			HTTPRequest: newHTTPRequest(),
+		}, nil).Match()
+		assert.Error(t, err)
+	})
+}
+
 func newHTTPRequest() *http.Request {
 	req, _ := http.NewRequest(
 		"POST",
@@ -1,13 +1,7 @@
 package matcher
 
 import (
-	"encoding/json"
-	"io/ioutil"
 	"strconv"
-	"strings"
-
-	"github.com/itchyny/gojq"
-	"github.com/pkg/errors"
 
 	"github.com/mockingio/mockingio/engine/database"
 	cfg "github.com/mockingio/mockingio/engine/mock"
@@ -20,6 +14,30 @@ var targets = map[cfg.Target]getTargetValueFn{
 	cfg.RequestNumber: getRequestNumber,
 	cfg.RouteParam:    getValueFromRouteParam,
 	cfg.Body:          getValueFromBody,
+	cfg.Message:       getValueFromMessage,
+
+	cfg.GraphQLOperationName: getGraphQLOperationName,
+	cfg.GraphQLQuery:         getGraphQLQuery,
+	cfg.GraphQLVariable:      getGraphQLVariable,
+
+	cfg.FormField:            getFormField,
+	cfg.MultipartContent:     getMultipartContent,
+	cfg.MultipartFilename:    getMultipartFilename,
+	cfg.MultipartContentType: getMultipartContentType,
+	cfg.XPath:                getXPath,
+
+	cfg.Method:        getMethod,
+	cfg.Path:          getPath,
+	cfg.RawQuery:      getRawQuery,
+	cfg.Host:          getHost,
+	cfg.Scheme:        getScheme,
+	cfg.RemoteIP:      getRemoteIP,
+	cfg.Protocol:      getProtocol,
+	cfg.ContentLength: getContentLength,
+
+	cfg.ClientCertCN:          getClientCertCN,
+	cfg.ClientCertSAN:         getClientCertSAN,
+	cfg.ClientCertFingerprint: getClientCertFingerprint,
 }
 
 type getTargetValueFn func(mok *cfg.Mock, route *cfg.Route, modifier string, req Context, db database.EngineDB) (string, error)
@@ -51,32 +69,13 @@ func getRequestNumber(mok *cfg.Mock, _ *cfg.Route, _ string, req Context, db dat
 }
 
 func getValueFromRouteParam(_ *cfg.Mock, route *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
-	templateParts := strings.Split(route.Path, "/")
-	actualParts := strings.Split(req.HTTPRequest.URL.Path, "/")
-	if len(templateParts) != len(actualParts) {
-		return "", nil
-	}
-
-	for i, templatePart := range templateParts {
-		if p, ok := param(templatePart); ok {
-			if p == modifier {
-				return actualParts[i], nil
-			}
-		}
-	}
-
-	return "", nil
+	return RouteParams(route.Path, req.HTTPRequest.URL.Path)[modifier], nil
 }
 
 func getValueFromBody(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
-	httpRequest := req.HTTPRequest
-	if httpRequest.Body == nil {
-		return "", nil
-	}
-
-	value, err := ioutil.ReadAll(httpRequest.Body)
+	value, err := ReadBody(req.HTTPRequest)
 	if err != nil {
-		return "", errors.Wrap(err, "read request body")
+		return "", err
 	}
 
 	if string(value) == "" {
@@ -87,32 +86,73 @@ func getValueFromBody(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _
 		return string(value), nil
 	}
 
-	input := map[string]interface{}{}
-	if err := json.Unmarshal(value, &input); err != nil {
-		return "", errors.Wrap(err, "unmarshal body")
+	return QueryJSON(value, modifier)
+}
+
+func getValueFromMessage(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	if modifier == "" || req.Message == "" {
+		return req.Message, nil
 	}
 
-	query, err := gojq.Parse(modifier)
+	return QueryJSON([]byte(req.Message), modifier)
+}
+
+func getGraphQLOperationName(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	operation, err := ReadGraphQL(req.HTTPRequest)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
+	return operation.OperationName, nil
+}
 
-	iter := query.Run(input)
-	for {
-		v, ok := iter.Next()
-		if !ok {
-			break
-		}
+func getGraphQLQuery(_ *cfg.Mock, _ *cfg.Route, _ string, req Context, _ database.EngineDB) (string, error) {
+	operation, err := ReadGraphQL(req.HTTPRequest)
+	if err != nil {
+		return "", err
+	}
+	return operation.Query, nil
+}
 
-		if v == nil {
-			return "", nil
-		}
+func getGraphQLVariable(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	operation, err := ReadGraphQL(req.HTTPRequest)
+	if err != nil {
+		return "", err
+	}
+	return operation.Variable(modifier)
+}
 
-		if err, ok := v.(error); ok {
-			return "", errors.Wrapf(err, "unable to parse json query: %v", modifier)
-		}
+func getFormField(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	return formValue(req.HTTPRequest, modifier)
+}
 
-		return v.(string), nil // nolint
+func getMultipartContent(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	part, err := formPart(req.HTTPRequest, modifier)
+	if err != nil || part == nil {
+		return "", err
 	}
-	return "", nil
+	return string(part.Content), nil
+}
+
+func getMultipartFilename(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	part, err := formPart(req.HTTPRequest, modifier)
+	if err != nil || part == nil {
+		return "", err
+	}
+	return part.Filename, nil
+}
+
+func getMultipartContentType(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	part, err := formPart(req.HTTPRequest, modifier)
+	if err != nil || part == nil {
+		return "", err
+	}
+	return part.ContentType, nil
+}
+
+func getXPath(_ *cfg.Mock, _ *cfg.Route, modifier string, req Context, _ database.EngineDB) (string, error) {
+	body, err := ReadBody(req.HTTPRequest)
+	if err != nil {
+		return "", err
+	}
+	return QueryXML(body, modifier)
 }
@@ -0,0 +1,41 @@
+package matcher
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/pkg/errors"
+)
+
+// QueryXML runs the XPath expression against the XML data and returns the text of the first node,
+// or the result of expressions returning a number, a string or a boolean, e.g. count(//item).
+func QueryXML(data []byte, expression string) (string, error) {
+	expr, err := xpath.Compile(expression)
+	if err != nil {
+		return "", errors.Wrapf(err, "compile XPath: %v", expression)
+	}
+
+	doc, err := xmlquery.Parse(bytes.NewReader(data))
+	if err != nil {
+		// a body which isn't XML doesn't match, it isn't an error
+		return "", nil
+	}
+
+	switch result := expr.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		if result.MoveNext() {
+			return result.Current().Value(), nil
+		}
+		return "", nil
+	case float64:
+		return strconv.FormatFloat(result, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(result), nil
+	case string:
+		return result, nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,54 @@
+package matcher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+)
+
+func TestQueryXML(t *testing.T) {
+	const soap = `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <GetOrder xmlns="http://example.com/orders">
+      <OrderID>42</OrderID>
+      <Item sku="A1">Book</Item>
+      <Item sku="B2">Pen</Item>
+    </GetOrder>
+  </soap:Body>
+</soap:Envelope>`
+
+	tests := []struct {
+		name       string
+		data       string
+		expression string
+		expected   string
+		error      bool
+	}{
+		{"element text", soap, "//OrderID", "42", false},
+		{"namespace prefix", soap, "/soap:Envelope/soap:Body/GetOrder/OrderID", "42", false},
+		{"local name", soap, "//*[local-name()='GetOrder']/*[local-name()='Item'][2]", "Pen", false},
+		{"attribute", soap, "//Item[. = 'Pen']/@sku", "B2", false},
+		{"count", soap, "count(//Item)", "2", false},
+		{"boolean", soap, "boolean(//OrderID)", "true", false},
+		{"string function", soap, "concat(//Item[1], '-', //Item[2])", "Book-Pen", false},
+		{"no node", soap, "//Customer", "", false},
+		{"not XML", `{"name": "joe"}`, "//name", "", false},
+		{"invalid expression", soap, "//Item[", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := matcher.QueryXML([]byte(tt.data), tt.expression)
+			if tt.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
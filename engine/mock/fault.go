@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type FaultType string
+
+const (
+	// FaultConnectionClose closes the connection without writing a response
+	FaultConnectionClose FaultType = "connection_close"
+	// FaultConnectionReset writes the headers and a part of the body, then resets the connection
+	FaultConnectionReset FaultType = "connection_reset"
+	// FaultTruncatedBody writes the headers and a part of the body, then closes the connection
+	FaultTruncatedBody FaultType = "truncated_body"
+	// FaultMalformedBody replaces the body, the file or the events of the response with random bytes
+	FaultMalformedBody FaultType = "malformed_body"
+	// FaultErrorStatus responds with an error status, picked randomly from the statuses
+	FaultErrorStatus FaultType = "error_status"
+	// FaultStall never responds, until the client gives up or the mock server stops
+	FaultStall FaultType = "stall"
+)
+
+// random picks the faults, rand.Rand is not safe for concurrent use
+var random = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// Fault breaks the response on purpose, for resilience testing
+type Fault struct {
+	Type FaultType `yaml:"type" json:"type"`
+	// Probability is the chance of the fault to happen, between 0 and 1. The fault always happens if not set,
+	// and never happens with 0.
+	Probability *float64 `yaml:"probability,omitempty" json:"probability,omitempty"`
+	// Statuses are the error statuses of the error_status fault, default to 500
+	Statuses []int `yaml:"statuses,omitempty" json:"statuses,omitempty"`
+}
+
+func (f Fault) Validate() error {
+	return validation.ValidateStruct(
+		&f,
+		validation.Field(&f.Type, validation.Required, validation.In(
+			FaultConnectionClose,
+			FaultConnectionReset,
+			FaultTruncatedBody,
+			FaultMalformedBody,
+			FaultErrorStatus,
+			FaultStall,
+		)),
+		validation.Field(&f.Probability, validation.Min(0.0), validation.Max(1.0)),
+		validation.Field(&f.Statuses, validation.Each(validation.Min(400), validation.Max(599))),
+	)
+}
+
+// Happens rolls the dice with the fault probability
+func (f Fault) Happens() bool {
+	if f.Probability == nil || *f.Probability >= 1 {
+		return true
+	}
+
+	random.Lock()
+	defer random.Unlock()
+
+	return random.Float64() < *f.Probability
+}
+
+// Status returns a random status of the error_status fault
+func (f Fault) Status() int {
+	if len(f.Statuses) == 0 {
+		return 500
+	}
+
+	random.Lock()
+	defer random.Unlock()
+
+	return f.Statuses[random.Intn(len(f.Statuses))]
+}
@@ -0,0 +1,58 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestFault_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fault   Fault
+		isValid bool
+	}{
+		{"valid", Fault{Type: FaultConnectionReset}, true},
+		{"valid error status", Fault{Type: FaultErrorStatus, Probability: probability(0.5), Statuses: []int{500, 503}}, true},
+		{"missing type", Fault{}, false},
+		{"invalid type", Fault{Type: "explode"}, false},
+		{"invalid probability", Fault{Type: FaultStall, Probability: probability(1.5)}, false},
+		{"invalid status", Fault{Type: FaultErrorStatus, Statuses: []int{200}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fault.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestFault_Happens(t *testing.T) {
+	assert.True(t, Fault{}.Happens())
+	assert.True(t, Fault{Probability: probability(1)}.Happens())
+	assert.False(t, Fault{Probability: probability(0)}.Happens())
+}
+
+func TestFault_Marshal(t *testing.T) {
+	var fault Fault
+	require.NoError(t, yaml.Unmarshal([]byte("type: stall\nprobability: 0"), &fault))
+	assert.False(t, fault.Happens())
+
+	data, err := yaml.Marshal(fault)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "probability: 0")
+}
+
+func TestFault_Status(t *testing.T) {
+	assert.Equal(t, 500, Fault{}.Status())
+	assert.Contains(t, []int{502, 503}, Fault{Statuses: []int{502, 503}}.Status())
+}
+
+func probability(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type Format string
+
+const (
+	YAML Format = "yaml"
+	JSON Format = "json"
+)
+
+// DetectFormat detects the format of a mock file by its extension, falls back to content sniffing.
+func DetectFormat(file string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return JSON
+	case ".yml", ".yaml":
+		return YAML
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+		return JSON
+	}
+
+	return YAML
+}
+
+// Marshal encodes the mock in the given format
+func (m Mock) Marshal(format Format) ([]byte, error) {
+	if format == JSON {
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal mock to json")
+		}
+		return data, nil
+	}
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal mock to yaml")
+	}
+	return data, nil
+}
@@ -0,0 +1,103 @@
+package mock
+
+import (
+	"errors"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// GRPC serves the mock methods on a gRPC listener, the services are described by
+// proto files or a descriptor set
+type GRPC struct {
+	// Port of the gRPC listener, random if empty
+	Port string `yaml:"port,omitempty" json:"port,omitempty"`
+	// ProtoFiles are relative to the import paths, which default to the mock file directory
+	ProtoFiles  []string `yaml:"proto_files,omitempty" json:"proto_files,omitempty"`
+	ImportPaths []string `yaml:"import_paths,omitempty" json:"import_paths,omitempty"`
+	// DescriptorSet is a file generated with protoc --descriptor_set_out --include_imports
+	DescriptorSet string        `yaml:"descriptor_set,omitempty" json:"descriptor_set,omitempty"`
+	Methods       []*GRPCMethod `yaml:"methods" json:"methods"`
+}
+
+func (g GRPC) Validate() error {
+	return validation.ValidateStruct(
+		&g,
+		validation.Field(&g.Port, is.Port),
+		validation.Field(&g.ProtoFiles, validation.When(g.DescriptorSet == "", validation.Required)),
+		validation.Field(&g.Methods, validation.Required),
+	)
+}
+
+type GRPCMethod struct {
+	ID string `yaml:"id,omitempty" json:"id,omitempty"`
+	// Name is the full method name, e.g. helloworld.Greeter/SayHello
+	Name      string         `yaml:"name" json:"name"`
+	Responses []GRPCResponse `yaml:"responses" json:"responses"`
+	Disabled  bool           `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+}
+
+func (m GRPCMethod) Validate() error {
+	return validation.ValidateStruct(
+		&m,
+		validation.Field(&m.Name, validation.Required, validation.By(func(value interface{}) error {
+			if !strings.Contains(strings.TrimPrefix(value.(string), "/"), "/") {
+				return errors.New("must be a full method name, e.g. package.Service/Method")
+			}
+			return nil
+		})),
+		validation.Field(&m.Responses, validation.Required),
+	)
+}
+
+// FullName returns the method name as sent by gRPC clients, e.g. /helloworld.Greeter/SayHello
+func (m GRPCMethod) FullName() string {
+	return "/" + strings.TrimPrefix(m.Name, "/")
+}
+
+// GRPCResponse is the reply of a method. Rules match the request message encoded as JSON with the body target,
+// an array of the messages for client streaming methods, and the request metadata with the header target.
+type GRPCResponse struct {
+	ID string `yaml:"id,omitempty" json:"id,omitempty"`
+	// Code is the gRPC status code, the response is an error with the message if not 0 (OK)
+	Code    int    `yaml:"code,omitempty" json:"code,omitempty"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	Delay   Delay  `yaml:"delay,omitempty" json:"delay,omitempty"`
+	// Metadata is sent as the response headers, and Trailers after the messages
+	Metadata map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	Trailers map[string]string `yaml:"trailers,omitempty" json:"trailers,omitempty"`
+	// Body is the JSON response message, it supports faker, and templates if enabled
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
+	// Template renders the body, stream, metadata and trailers as Go templates with the request data
+	Template bool `yaml:"template,omitempty" json:"template,omitempty"`
+	// Stream are the messages sent by server streaming methods, Body is sent if empty
+	Stream          []GRPCMessage   `yaml:"stream,omitempty" json:"stream,omitempty"`
+	RuleAggregation RuleAggregation `yaml:"rule_aggregation,omitempty" json:"rule_aggregation,omitempty"`
+	Rules           []Rule          `yaml:"rules,omitempty" json:"rules,omitempty"`
+	IsDefault       bool            `yaml:"is_default,omitempty" json:"is_default,omitempty"`
+}
+
+func (r GRPCResponse) Validate() error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(&r.Code, validation.Min(0), validation.Max(16)),
+		validation.Field(&r.Delay),
+		validation.Field(&r.Stream),
+		validation.Field(&r.RuleAggregation, validation.In(Or, And)),
+		validation.Field(&r.Rules),
+	)
+}
+
+// GRPCMessage is a message of a server stream, sent after the delay in milliseconds
+type GRPCMessage struct {
+	Body  string `yaml:"body" json:"body"`
+	Delay int    `yaml:"delay,omitempty" json:"delay,omitempty"`
+}
+
+func (m GRPCMessage) Validate() error {
+	return validation.ValidateStruct(
+		&m,
+		validation.Field(&m.Delay, validation.Min(0)),
+	)
+}
@@ -0,0 +1,43 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestGRPC_Validate(t *testing.T) {
+	method := &GRPCMethod{Name: "helloworld.Greeter/SayHello", Responses: []GRPCResponse{{Body: "{}"}}}
+
+	tests := []struct {
+		name    string
+		grpc    GRPC
+		isValid bool
+	}{
+		{"valid proto files", GRPC{ProtoFiles: []string{"greeter.proto"}, Methods: []*GRPCMethod{method}}, true},
+		{"valid descriptor set", GRPC{DescriptorSet: "greeter.pb", Methods: []*GRPCMethod{method}}, true},
+		{"missing descriptors", GRPC{Methods: []*GRPCMethod{method}}, false},
+		{"missing methods", GRPC{ProtoFiles: []string{"greeter.proto"}}, false},
+		{"invalid port", GRPC{Port: "port", ProtoFiles: []string{"greeter.proto"}, Methods: []*GRPCMethod{method}}, false},
+		{"invalid method name", GRPC{ProtoFiles: []string{"greeter.proto"}, Methods: []*GRPCMethod{
+			{Name: "SayHello", Responses: method.Responses},
+		}}, false},
+		{"invalid code", GRPC{ProtoFiles: []string{"greeter.proto"}, Methods: []*GRPCMethod{
+			{Name: method.Name, Responses: []GRPCResponse{{Code: 17}}},
+		}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.grpc.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestGRPCMethod_FullName(t *testing.T) {
+	assert.Equal(t, "/helloworld.Greeter/SayHello", GRPCMethod{Name: "helloworld.Greeter/SayHello"}.FullName())
+	assert.Equal(t, "/helloworld.Greeter/SayHello", GRPCMethod{Name: "/helloworld.Greeter/SayHello"}.FullName())
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -14,16 +15,32 @@ import (
 )
 
 type Mock struct {
-	ID     string   `yaml:"id,omitempty" json:"id,omitempty"`
-	Name   string   `yaml:"name,omitempty" json:"name,omitempty"`
-	Port   string   `yaml:"port,omitempty" json:"port,omitempty"`
-	Routes []*Route `yaml:"routes,omitempty" json:"routes,omitempty"`
-	Proxy  *Proxy   `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	ID   string `yaml:"id,omitempty" json:"id,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	Port string `yaml:"port,omitempty" json:"port,omitempty"`
+	// Hosts are the virtual hosts of the mock, matched against the Host header when mocks share a port.
+	// Wildcards are supported, e.g. *.example.com
+	Hosts []string `yaml:"hosts,omitempty" json:"hosts,omitempty"`
+	// PathPrefix routes the requests starting with the prefix to the mock when mocks share a port,
+	// the prefix is removed before the routes are matched
+	PathPrefix string   `yaml:"path_prefix,omitempty" json:"path_prefix,omitempty"`
+	Routes     []*Route `yaml:"routes,omitempty" json:"routes,omitempty"`
+	// Resources are in-memory REST collections, served when no route is matched
+	Resources []*Resource `yaml:"resources,omitempty" json:"resources,omitempty"`
+	Proxy     *Proxy      `yaml:"proxy,omitempty" json:"proxy,omitempty"`
 	// all OPTIONS calls are responded with success if AutoCORS is true
 	AutoCORS bool `yaml:"auto_cors,omitempty" json:"auto_cors,omitempty"`
 	TLS      *TLS `yaml:"tls,omitempty" json:"tls,omitempty"`
-	options  mockOptions
-	FilePath string `yaml:"-" json:"-"`
+	// Fault breaks all responses of the mock on purpose, unless the response has its own fault
+	Fault *Fault `yaml:"fault,omitempty" json:"fault,omitempty"`
+	// GRPC serves gRPC methods on a separate listener
+	GRPC *GRPC `yaml:"grpc,omitempty" json:"grpc,omitempty"`
+	// Validation rejects the requests not conforming to an OpenAPI document
+	Validation *Validation `yaml:"validation,omitempty" json:"validation,omitempty"`
+	options    mockOptions
+	FilePath   string `yaml:"-" json:"-"`
+	// FileFormat is the format of the file the mock was loaded from
+	FileFormat Format `yaml:"-" json:"-"`
 }
 
 func New(opts ...Option) *Mock {
@@ -39,18 +56,25 @@ func New(opts ...Option) *Mock {
 }
 
 func FromFile(file string, opts ...Option) (*Mock, error) {
-	// TODO: Detects file type, support JSON
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return nil, errors.Wrap(err, "read mock file")
 	}
 
-	mok, err := FromYaml(string(data), opts...)
+	format := DetectFormat(file, data)
+
+	var mok *Mock
+	if format == JSON {
+		mok, err = FromJSON(string(data), opts...)
+	} else {
+		mok, err = FromYaml(string(data), opts...)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "parse mock file")
 	}
 
 	mok.FilePath = file
+	mok.FileFormat = format
 
 	return mok, err
 }
@@ -61,7 +85,24 @@ func FromYaml(text string, opts ...Option) (*Mock, error) {
 	if err := decoder.Decode(m); err != nil {
 		return nil, errors.Wrap(err, "decode yaml to mock")
 	}
+
+	return prepare(m)
+}
+
+func FromJSON(text string, opts ...Option) (*Mock, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	m := New(opts...)
+	if err := decoder.Decode(m); err != nil {
+		return nil, errors.Wrap(err, "decode json to mock")
+	}
+
+	return prepare(m)
+}
+
+// prepare applies defaults, generates IDs if needed and validates the decoded mock
+func prepare(m *Mock) (*Mock, error) {
 	m.ApplyDefault()
+
 	if m.options.idGeneration {
 		addIDs(m)
 	}
@@ -79,7 +120,14 @@ func (m Mock) Validate() error {
 		validation.Field(&m.ID, validation.Length(0, 100)),
 		validation.Field(&m.Name, validation.Length(0, 255)),
 		validation.Field(&m.Port, is.Port),
-		validation.Field(&m.Routes, validation.Required),
+		validation.Field(&m.Hosts, validation.Each(validation.Required, validation.Match(hostRegex))),
+		validation.Field(&m.PathPrefix, validation.Match(pathPrefixRegex)),
+		validation.Field(&m.Routes, validation.When(len(m.Resources) == 0 && m.GRPC == nil, validation.Required)),
+		validation.Field(&m.Resources),
+		validation.Field(&m.Fault),
+		validation.Field(&m.GRPC),
+		validation.Field(&m.Validation),
+		validation.Field(&m.TLS),
 	)
 }
 
@@ -91,6 +139,15 @@ func (m Mock) TLSEnabled() bool {
 	return m.TLS != nil && m.TLS.Enabled
 }
 
+// ResolvePath returns the path of a file referenced by the mock, relative paths are relative to the mock file
+func (m Mock) ResolvePath(filepath string) string {
+	if path.IsAbs(filepath) {
+		return filepath
+	}
+
+	return path.Join(path.Dir(m.FilePath), filepath)
+}
+
 func (m Mock) JSON() (string, error) {
 	data, err := json.Marshal(m)
 	if err != nil {
@@ -100,6 +157,24 @@ func (m Mock) JSON() (string, error) {
 	return string(data), nil
 }
 
+// Clone returns a deep copy of the mock, changes of the copy don't affect the mock
+func (m Mock) Clone() (*Mock, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal mock to json")
+	}
+
+	clone := &Mock{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, errors.Wrap(err, "unmarshal mock from json")
+	}
+	clone.options = m.options
+	clone.FilePath = m.FilePath
+	clone.FileFormat = m.FileFormat
+
+	return clone, nil
+}
+
 func (m Mock) ApplyDefault() Mock {
 	for _, r := range m.Routes {
 		if r.Method == "" {
@@ -124,6 +199,10 @@ func addIDs(m *Mock) {
 	if m.ID == "" {
 		m.ID = newID()
 	}
+	if m.GRPC != nil {
+		addGRPCIDs(m.GRPC)
+	}
+
 	for _, r := range m.Routes {
 		if r.ID == "" {
 			r.ID = newID()
@@ -141,6 +220,38 @@ func addIDs(m *Mock) {
 					res.Rules[j] = rule
 				}
 			}
+
+			if res.Match != nil {
+				addRuleGroupIDs(res.Match)
+			}
+		}
+	}
+}
+
+// addRuleGroupIDs adds ids to the rules of the group, and of its nested groups
+func addRuleGroupIDs(g *RuleGroup) {
+	if g.IsRule() && g.ID == "" {
+		g.ID = newID()
+	}
+
+	for _, groups := range [][]RuleGroup{g.All, g.Any, g.None} {
+		for i := range groups {
+			addRuleGroupIDs(&groups[i])
+		}
+	}
+}
+
+func addGRPCIDs(g *GRPC) {
+	for _, method := range g.Methods {
+		if method.ID == "" {
+			method.ID = newID()
+		}
+
+		for i, res := range method.Responses {
+			if res.ID == "" {
+				res.ID = newID()
+				method.Responses[i] = res
+			}
 		}
 	}
 }
@@ -27,6 +27,7 @@ func TestConfig(t *testing.T) {
 		test.UpdateGoldenFile(t, goldenFile, text)
 
 		assert.Equal(t, "fixtures/mock.yml", cfg.FilePath)
+		assert.Equal(t, YAML, cfg.FileFormat)
 		assert.Equal(t, test.ReadGoldenFile(t, goldenFile), string(text))
 	})
 
@@ -40,6 +41,60 @@ func TestConfig(t *testing.T) {
 		assert.True(t, mock.Routes[0].Responses[0].Rules[0].ID != "")
 	})
 
+	t.Run("ID generation option adds IDs to rules in match groups", func(t *testing.T) {
+		mock, err := FromYaml(`
+routes:
+  - path: /hello
+    responses:
+      - status: 200
+        match:
+          any:
+            - all:
+                - target: header
+                  modifier: X-Name
+                  operator: equal
+                  value: foo
+            - none:
+                - target: query_string
+                  modifier: name
+                  operator: equal
+                  value: bar
+`, WithIDGeneration())
+		require.NoError(t, err)
+
+		match := mock.Routes[0].Responses[0].Match
+		require.NotNil(t, match)
+		assert.True(t, match.Any[0].All[0].ID != "")
+		assert.True(t, match.Any[1].None[0].ID != "")
+		assert.Equal(t, "", match.Any[0].ID)
+	})
+
+	t.Run("Load mock from JSON file", func(t *testing.T) {
+		mock, err := FromFile("fixtures/mock.json", WithIDGeneration())
+		require.NoError(t, err)
+
+		assert.Equal(t, JSON, mock.FileFormat)
+		assert.True(t, mock.ID != "")
+		assert.Equal(t, "Hello World", mock.Name)
+		assert.Equal(t, "GET", mock.Routes[0].Method)
+		assert.Equal(t, 200, mock.Routes[0].Responses[0].Status)
+		assert.True(t, mock.Routes[0].Responses[0].Rules[0].ID != "")
+	})
+
+	t.Run("Load mock from JSON file without extension", func(t *testing.T) {
+		mock, err := FromFile("fixtures/mock_json_no_ext")
+		require.NoError(t, err)
+
+		assert.Equal(t, JSON, mock.FileFormat)
+		assert.Equal(t, "Hello World", mock.Name)
+	})
+
+	t.Run("error loading mock from invalid json", func(t *testing.T) {
+		mock, err := FromJSON(`{"routes": []}`)
+		assert.Error(t, err)
+		assert.Nil(t, mock)
+	})
+
 	t.Run("When method, status is not presented, use default GET/200 as response", func(t *testing.T) {
 		mock, err := FromFile("fixtures/mock_no_method_status.yml")
 		require.NoError(t, err)
@@ -60,6 +115,40 @@ func TestConfig(t *testing.T) {
 		assert.Nil(t, mock)
 	})
 
+	t.Run("error loading mock with an invalid rule", func(t *testing.T) {
+		mock, err := FromYaml(`
+routes:
+  - path: /hello
+    responses:
+      - status: 200
+        rules:
+          - target: header
+            modifier: X-Count
+            operator: gt
+            value: abc
+`)
+		assert.Error(t, err)
+		assert.Nil(t, mock)
+	})
+
+	t.Run("load rule values from a list", func(t *testing.T) {
+		mock, err := FromYaml(`
+routes:
+  - path: /hello
+    responses:
+      - status: 200
+        rules:
+          - target: header
+            modifier: Accept
+            operator: in
+            values:
+              - text/html, application/xhtml+xml
+              - application/json
+`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"text/html, application/xhtml+xml", "application/json"}, mock.Routes[0].Responses[0].Rules[0].ValueList())
+	})
+
 	t.Run("proxy is enabled", func(t *testing.T) {
 		mock := &Mock{
 			Proxy: &Proxy{
@@ -107,6 +196,24 @@ func TestMock_Validate(t *testing.T) {
 		{
 			"no routes", Mock{Routes: []*Route{}}, false,
 		},
+		{
+			"resources only", Mock{Resources: []*Resource{{Path: "/products"}}}, true,
+		},
+		{
+			"invalid resource", Mock{Resources: []*Resource{{Path: "/products/:id"}}}, false,
+		},
+		{
+			"resource path without a leading slash", Mock{Resources: []*Resource{{Path: "products"}}}, false,
+		},
+		{
+			"virtual hosts", Mock{Hosts: []string{"users.local", "*.example.com"}, PathPrefix: "/users", Routes: validRoutes}, true,
+		},
+		{
+			"invalid host", Mock{Hosts: []string{"http://users.local"}, Routes: validRoutes}, false,
+		},
+		{
+			"invalid path prefix", Mock{PathPrefix: "users", Routes: validRoutes}, false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,3 +223,103 @@ func TestMock_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		data   string
+		format Format
+	}{
+		{"json extension", "mock.json", "", JSON},
+		{"yaml extension", "mock.yaml", `{"name": "json"}`, YAML},
+		{"yml extension", "mock.YML", "", YAML},
+		{"json content", "mock", ` {"name": "json"}`, JSON},
+		{"yaml content", "mock", "name: yaml", YAML},
+		{"yaml flow content", "mock", "{name: yaml}", YAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.format, DetectFormat(tt.file, []byte(tt.data)))
+		})
+	}
+}
+
+func TestMock_Marshal(t *testing.T) {
+	mock, err := FromFile("fixtures/mock.json")
+	require.NoError(t, err)
+
+	data, err := mock.Marshal(JSON)
+	require.NoError(t, err)
+	fromJSON, err := FromJSON(string(data))
+	require.NoError(t, err)
+	assert.Equal(t, mock.Routes, fromJSON.Routes)
+
+	data, err = mock.Marshal(YAML)
+	require.NoError(t, err)
+	fromYaml, err := FromYaml(string(data))
+	require.NoError(t, err)
+	assert.Equal(t, mock.Routes, fromYaml.Routes)
+}
+
+func TestMock_Clone(t *testing.T) {
+	mock, err := FromFile("fixtures/mock.yml")
+	require.NoError(t, err)
+
+	clone, err := mock.Clone()
+	require.NoError(t, err)
+	assert.Equal(t, mock, clone)
+
+	clone.Routes[0].Responses = append(clone.Routes[0].Responses, Response{Status: 500})
+	clone.Routes = append(clone.Routes, &Route{Path: "/new"})
+	assert.NotEqual(t, len(mock.Routes), len(clone.Routes))
+	assert.NotEqual(t, len(mock.Routes[0].Responses), len(clone.Routes[0].Responses))
+}
+
+func TestMock_MatchHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		hosts    []string
+		host     string
+		expected bool
+	}{
+		{"no hosts", nil, "example.com", true},
+		{"host", []string{"users.local"}, "users.local", true},
+		{"host with port", []string{"users.local"}, "users.local:8080", true},
+		{"case insensitive", []string{"Users.Local"}, "users.local", true},
+		{"wildcard", []string{"*.example.com"}, "api.example.com", true},
+		{"other host", []string{"users.local", "*.example.com"}, "orders.local", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Mock{Hosts: tt.hosts}.MatchHost(tt.host))
+		})
+	}
+}
+
+func TestMock_TrimPathPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		path     string
+		expected string
+		matched  bool
+	}{
+		{"no prefix", "", "/users", "/users", true},
+		{"prefix", "/users", "/users/1", "/1", true},
+		{"trailing slash", "/users/", "/users/1", "/1", true},
+		{"prefix only", "/users", "/users", "/", true},
+		{"partial segment", "/users", "/users-v2/1", "", false},
+		{"other path", "/users", "/orders", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, matched := Mock{PathPrefix: tt.prefix}.TrimPathPrefix(tt.path)
+			assert.Equal(t, tt.matched, matched)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
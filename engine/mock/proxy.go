@@ -8,6 +8,11 @@ type Proxy struct {
 	RequestHeaders     map[string]string `yaml:"request_headers,omitempty" json:"request_headers,omitempty"`
 	ResponseHeaders    map[string]string `yaml:"response_headers,omitempty" json:"response_headers,omitempty"`
 	InsecureSkipVerify bool              `yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+	Record             *Record           `yaml:"record,omitempty" json:"record,omitempty"`
+}
+
+func (p Proxy) RecordEnabled() bool {
+	return p.Record != nil && p.Record.Enabled
 }
 
 func (p Proxy) Validate() error {
@@ -0,0 +1,16 @@
+package mock
+
+// Record saves the proxied requests and responses as routes of the mock
+type Record struct {
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// RedactHeaders are request and response headers which values are replaced before saving
+	RedactHeaders []string `yaml:"redact_headers,omitempty" json:"redact_headers,omitempty"`
+	// RedactBodyFields are JSON fields, at any level, which values are replaced before saving
+	RedactBodyFields []string `yaml:"redact_body_fields,omitempty" json:"redact_body_fields,omitempty"`
+	// MatchHeaders are request headers saved as response rules, missing headers are saved as absent rules
+	MatchHeaders []string `yaml:"match_headers,omitempty" json:"match_headers,omitempty"`
+	// MatchQueryStrings saves all request query strings as response rules, or an absent raw query rule
+	MatchQueryStrings bool `yaml:"match_query_strings,omitempty" json:"match_query_strings,omitempty"`
+	// MatchBody saves the request body as response rule, or an absent body rule
+	MatchBody bool `yaml:"match_body,omitempty" json:"match_body,omitempty"`
+}
@@ -0,0 +1,43 @@
+package mock
+
+import (
+	"errors"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const defaultIDField = "id"
+
+// Resource is an in-memory REST collection, e.g. /products serves
+// POST /products, GET /products, GET/PUT/PATCH/DELETE /products/:id
+type Resource struct {
+	Path string `yaml:"path" json:"path"`
+	// IDField is the field identifying an item, default to "id"
+	IDField string `yaml:"id_field,omitempty" json:"id_field,omitempty"`
+	// SeedFile is a JSON file with the initial items, relative to the mock file
+	SeedFile string `yaml:"seed_file,omitempty" json:"seed_file,omitempty"`
+}
+
+func (r Resource) Validate() error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(&r.Path, validation.Required, validation.By(func(value interface{}) error {
+			if !strings.HasPrefix(value.(string), "/") {
+				return errors.New("resource path must start with /")
+			}
+			if strings.Contains(value.(string), ":") || strings.Contains(value.(string), "*") {
+				return errors.New("resource path must not contain params or wildcards")
+			}
+			return nil
+		})),
+	)
+}
+
+// ID returns the field identifying an item
+func (r Resource) ID() string {
+	if r.IDField == "" {
+		return defaultIDField
+	}
+	return r.IDField
+}
@@ -21,6 +21,22 @@ type Response struct {
 	RuleAggregation RuleAggregation   `yaml:"rule_aggregation,omitempty" json:"rule_aggregation,omitempty"`
 	Rules           []Rule            `yaml:"rules,omitempty" json:"rules,omitempty"`
 	IsDefault       bool              `yaml:"is_default,omitempty" json:"is_default,omitempty"`
+	// Match is a group of rules nested with all, any and none, it must match as well as the rules
+	Match *RuleGroup `yaml:"match,omitempty" json:"match,omitempty"`
+	// Template renders the body and headers as Go templates with the request data, e.g. {{ param "id" }}
+	Template bool `yaml:"template,omitempty" json:"template,omitempty"`
+	// SSE streams server-sent events instead of the body
+	SSE *SSE `yaml:"sse,omitempty" json:"sse,omitempty"`
+	// Throttle slows down the response to simulate slow networks
+	Throttle *Throttle `yaml:"throttle,omitempty" json:"throttle,omitempty"`
+	// Fault breaks the response on purpose, it takes precedence over the mock fault
+	Fault *Fault `yaml:"fault,omitempty" json:"fault,omitempty"`
+	// Scenario is the name of the scenario the response takes part in
+	Scenario string `yaml:"scenario,omitempty" json:"scenario,omitempty"`
+	// RequiredState is the scenario state required to match the response
+	RequiredState string `yaml:"required_state,omitempty" json:"required_state,omitempty"`
+	// NewState is the scenario state set when the response is served
+	NewState string `yaml:"new_state,omitempty" json:"new_state,omitempty"`
 }
 
 func (r Response) Validate() error {
@@ -28,5 +44,12 @@ func (r Response) Validate() error {
 		&r,
 		validation.Field(&r.Status, validation.Min(100), validation.Max(999)),
 		validation.Field(&r.RuleAggregation, validation.In(Or, And)),
+		validation.Field(&r.Rules),
+		validation.Field(&r.Match),
+		validation.Field(&r.SSE),
+		validation.Field(&r.Throttle),
+		validation.Field(&r.Fault),
+		validation.Field(&r.RequiredState, validation.When(r.Scenario == "", validation.Empty)),
+		validation.Field(&r.NewState, validation.When(r.Scenario == "", validation.Empty)),
 	)
 }
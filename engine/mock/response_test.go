@@ -16,6 +16,13 @@ func TestResponse_Validate(t *testing.T) {
 		{"valid status 200", Response{Status: http.StatusOK, RuleAggregation: Or}, false},
 		{"default, no status", Response{}, false},
 		{"invalid status", Response{Status: 9999}, true},
+		{"valid rule", Response{Rules: []Rule{{Target: Header, Modifier: "X-Count", Operator: GreaterThan, Value: "1"}}}, false},
+		{"invalid rule target", Response{Rules: []Rule{{Target: "bogus", Operator: Equal, Value: "1"}}}, true},
+		{"invalid rule operator", Response{Rules: []Rule{{Target: Body, Operator: "nope", Value: "1"}}}, true},
+		{"invalid numeric rule value", Response{Rules: []Rule{{Target: Header, Modifier: "X-Count", Operator: GreaterThan, Value: "abc"}}}, true},
+		{"scenario", Response{Scenario: "checkout", RequiredState: "cart", NewState: "paid"}, false},
+		{"scenario state without scenario", Response{RequiredState: "cart"}, true},
+		{"scenario new state without scenario", Response{NewState: "paid"}, true},
 	}
 
 	for _, tt := range tests {
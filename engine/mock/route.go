@@ -23,6 +23,7 @@ type Route struct {
 	ResponseMode responseMode `yaml:"response_mode,omitempty" json:"response_mode,omitempty"`
 	Responses    []Response   `yaml:"responses" json:"responses"`
 	Disabled     bool         `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	WebSocket    *WebSocket   `yaml:"websocket,omitempty" json:"websocket,omitempty"`
 }
 
 func (r Route) Validate() error {
@@ -38,7 +39,8 @@ func (r Route) Validate() error {
 		})),
 		validation.Field(&r.Path, validation.Required),
 		validation.Field(&r.ResponseMode, validation.In(DefaultResponse, ResponseRandomly, ResponseSequentially)),
-		validation.Field(&r.Responses, validation.Required),
+		validation.Field(&r.Responses, validation.When(r.WebSocket == nil, validation.Required)),
+		validation.Field(&r.WebSocket),
 	)
 }
 
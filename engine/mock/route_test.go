@@ -21,6 +21,8 @@ func TestRoute_Validate(t *testing.T) {
 		{"invalid route, missing request", Route{Responses: validResponse}, true},
 		{"invalid route, missing response", Route{Method: "POST", Path: "/"}, true},
 		{"invalid route, invalid response", Route{Method: "POST", Path: "/", Responses: []Response{}}, true},
+		{"valid websocket route without responses", Route{Method: "GET", Path: "/ws", WebSocket: &WebSocket{}}, false},
+		{"invalid websocket route", Route{Method: "GET", Path: "/ws", WebSocket: &WebSocket{Close: &Close{Code: 1}}}, true},
 	}
 
 	for _, tt := range tests {
@@ -1,6 +1,14 @@
 package mock
 
 import (
+	"encoding/json"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xpath"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -14,26 +22,210 @@ const (
 	Cookie        Target = "cookie"
 	RouteParam    Target = "route_param"
 	RequestNumber Target = "request_number"
+	// Message is the WebSocket message received from the client
+	Message Target = "message"
+
+	GraphQLOperationName Target = "graphql_operation_name"
+	GraphQLQuery         Target = "graphql_query"
+	// GraphQLVariable modifier is a gojq query on the operation variables
+	GraphQLVariable Target = "graphql_variable"
+
+	// FormField modifier is the name of a field of an application/x-www-form-urlencoded or multipart/form-data body
+	FormField Target = "form_field"
+	// MultipartContent modifier is the name of a part of a multipart/form-data body, e.g. a file
+	MultipartContent Target = "multipart_content"
+	// MultipartFilename modifier is the name of a file part of a multipart/form-data body
+	MultipartFilename Target = "multipart_filename"
+	// MultipartContentType modifier is the name of a part of a multipart/form-data body
+	MultipartContentType Target = "multipart_content_type"
+	// XPath modifier is an XPath expression over an XML body, e.g. //order/id or count(//item)
+	XPath Target = "xpath"
+
+	Method Target = "method"
+	// Path is the full path of the request, including the path prefix of the mock
+	Path     Target = "path"
+	RawQuery Target = "raw_query"
+	// Host is the host of the request, without the port
+	Host   Target = "host"
+	Scheme Target = "scheme"
+	// RemoteIP is the IP of the client, it can be matched with the cidr operator
+	RemoteIP Target = "remote_ip"
+	// Protocol is the HTTP version of the request, e.g. HTTP/1.1
+	Protocol      Target = "protocol"
+	ContentLength Target = "content_length"
+
+	// ClientCertCN is the subject common name of the client certificate, with mutual TLS
+	ClientCertCN Target = "client_cert_cn"
+	// ClientCertSAN is the comma separated subject alternative names of the client certificate,
+	// the modifier selects one type of names: dns, email, ip or uri
+	ClientCertSAN Target = "client_cert_san"
+	// ClientCertFingerprint is the SHA-256 fingerprint of the client certificate, in lowercase hex
+	ClientCertFingerprint Target = "client_cert_fingerprint"
 )
 
 const (
-	Equal Operator = "equal"
-	Regex Operator = "regex"
+	Equal    Operator = "equal"
+	NotEqual Operator = "not_equal"
+	// EqualIgnoreCase is a case-insensitive equal
+	EqualIgnoreCase Operator = "equal_ignore_case"
+	Regex           Operator = "regex"
+	Contains        Operator = "contains"
+	StartsWith      Operator = "starts_with"
+	EndsWith        Operator = "ends_with"
+	// In matches one of the values, or of the comma separated values of the value
+	In Operator = "in"
+	// Exists matches a non-empty target, the rule has no value
+	Exists Operator = "exists"
+	// Absent matches an empty or missing target, the rule has no value
+	Absent Operator = "absent"
+
+	GreaterThan        Operator = "gt"
+	GreaterThanOrEqual Operator = "gte"
+	LessThan           Operator = "lt"
+	LessThanOrEqual    Operator = "lte"
+	// Between matches a number in the inclusive range of the value, e.g. "1,10"
+	Between Operator = "between"
+
+	// JSONContains matches a JSON target containing the JSON value, extra fields and array elements are allowed
+	JSONContains Operator = "json_contains"
+	// JSONSchema matches a JSON target valid against the schema of the value,
+	// an inline JSON schema or a schema file relative to the mock file
+	JSONSchema Operator = "json_schema"
+
+	// CIDR matches an IP in one of the CIDR ranges or IPs of the values, or of the comma separated value, e.g. "10.0.0.0/8,127.0.0.1"
+	CIDR Operator = "cidr"
 )
 
+var operators = []interface{}{
+	Equal, NotEqual, EqualIgnoreCase, Regex, Contains, StartsWith, EndsWith, In, Exists, Absent,
+	GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual, Between,
+	JSONContains, JSONSchema, CIDR,
+}
+
 type Rule struct {
 	ID       string   `yaml:"id,omitempty" json:"id,omitempty"`
 	Target   Target   `yaml:"target" json:"target"`
 	Modifier string   `yaml:"modifier" json:"modifier,omitempty"`
 	Value    string   `yaml:"value" json:"value"`
 	Operator Operator `yaml:"operator" json:"operator"`
+	// Values are the values of the in, cidr and between operators, in place of the comma separated value
+	Values []string `yaml:"values,omitempty" json:"values,omitempty"`
+	// IgnoreArrayOrder matches the array elements in any order, with the json_contains operator
+	IgnoreArrayOrder bool `yaml:"ignore_array_order,omitempty" json:"ignore_array_order,omitempty"`
 }
 
 func (r Rule) Validate() error {
 	return validation.ValidateStruct(
 		&r,
-		validation.Field(&r.Target, validation.Required, validation.In(Body, QueryString, Header, Cookie, RouteParam, RequestNumber)),
-		validation.Field(&r.Value, validation.Required),
-		validation.Field(&r.Operator, validation.Required, validation.In(Equal, Regex)),
+		validation.Field(&r.Target, validation.Required, validation.In(
+			Body, QueryString, Header, Cookie, RouteParam, RequestNumber, Message,
+			GraphQLOperationName, GraphQLQuery, GraphQLVariable,
+			ClientCertCN, ClientCertSAN, ClientCertFingerprint,
+			FormField, MultipartContent, MultipartFilename, MultipartContentType, XPath,
+			Method, Path, RawQuery, Host, Scheme, RemoteIP, Protocol, ContentLength,
+		)),
+		validation.Field(&r.Modifier,
+			validation.When(r.Target == ClientCertSAN, validation.In("dns", "email", "ip", "uri")),
+			validation.When(r.requiresModifier(), validation.Required),
+			validation.When(r.Target == XPath, validation.By(validateXPath)),
+		),
+		validation.Field(&r.Value,
+			validation.When(r.Operator != Exists && r.Operator != Absent && len(r.Values) == 0, validation.Required),
+			validation.By(r.validateValue),
+		),
+		validation.Field(&r.Values, validation.When(r.Operator != In && r.Operator != CIDR && r.Operator != Between, validation.Empty)),
+		validation.Field(&r.Operator, validation.Required, validation.In(operators...)),
 	)
 }
+
+// ValueList returns the values of the rule, e.g. of the in operator, or the comma separated values of the value
+func (r Rule) ValueList() []string {
+	if len(r.Values) > 0 {
+		return r.Values
+	}
+
+	values := strings.Split(r.Value, ",")
+	for i, value := range values {
+		values[i] = strings.TrimSpace(value)
+	}
+
+	return values
+}
+
+// Numbers returns the numbers of the value of numeric operators, two numbers for between
+func (r Rule) Numbers() ([]float64, error) {
+	values := []string{strings.TrimSpace(r.Value)}
+	if r.Operator == Between {
+		values = r.ValueList()
+		if len(values) != 2 {
+			return nil, errors.New("must be two comma separated numbers")
+		}
+	}
+
+	numbers := make([]float64, len(values))
+	for i, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		numbers[i] = number
+	}
+
+	if len(numbers) == 2 && numbers[0] > numbers[1] {
+		return nil, errors.New("the minimum must not be greater than the maximum")
+	}
+
+	return numbers, nil
+}
+
+// IsNumeric returns true if the operator compares numbers
+func (r Rule) IsNumeric() bool {
+	switch r.Operator {
+	case GreaterThan, GreaterThanOrEqual, LessThan, LessThanOrEqual, Between:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r Rule) requiresModifier() bool {
+	switch r.Target {
+	case FormField, MultipartContent, MultipartFilename, MultipartContentType, XPath:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateXPath(value interface{}) error {
+	expression, _ := value.(string)
+	if _, err := xpath.Compile(expression); err != nil {
+		return errors.New("must be a valid XPath expression")
+	}
+
+	return nil
+}
+
+func (r Rule) validateValue(_ interface{}) error {
+	switch {
+	case r.IsNumeric():
+		_, err := r.Numbers()
+		return err
+	case r.Operator == Regex:
+		if _, err := regexp.Compile(r.Value); err != nil {
+			return errors.New("must be a valid regular expression")
+		}
+	case r.Operator == CIDR:
+		for _, value := range r.ValueList() {
+			if _, _, err := net.ParseCIDR(value); err != nil && net.ParseIP(value) == nil {
+				return errors.New("must be comma separated CIDR ranges or IPs")
+			}
+		}
+	case r.Operator == JSONContains, r.Operator == JSONSchema && strings.HasPrefix(strings.TrimSpace(r.Value), "{"):
+		if !json.Valid([]byte(r.Value)) {
+			return errors.New("must be valid JSON")
+		}
+	}
+
+	return nil
+}
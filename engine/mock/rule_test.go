@@ -16,6 +16,32 @@ func TestRule_Validate(t *testing.T) {
 		{"invalid route, missing target", Rule{Target: "", Modifier: "Authorization", Value: "Bearer...", Operator: "equal"}, true},
 		{"invalid route, missing value", Rule{Target: "cookie", Modifier: "Authorization", Value: "", Operator: "equal"}, true},
 		{"invalid route, missing operator", Rule{Target: "body", Modifier: "Authorization", Value: "Bearer...", Operator: ""}, true},
+		{"valid exists rule without value", Rule{Target: "header", Modifier: "Authorization", Operator: "exists"}, false},
+		{"valid in rule", Rule{Target: "header", Modifier: "Accept", Value: "text/html,application/json", Operator: "in"}, false},
+		{"valid in rule with values", Rule{Target: "header", Modifier: "Accept", Values: []string{"text/html, application/xhtml+xml", "application/json"}, Operator: "in"}, false},
+		{"invalid values, not a list operator", Rule{Target: "header", Modifier: "Accept", Values: []string{"text/html"}, Operator: "equal"}, true},
+		{"valid number rule", Rule{Target: "body", Modifier: ".age", Value: "18", Operator: "gte"}, false},
+		{"valid between rule", Rule{Target: "body", Modifier: ".age", Value: "18, 65.5", Operator: "between"}, false},
+		{"invalid number", Rule{Target: "body", Modifier: ".age", Value: "eighteen", Operator: "gt"}, true},
+		{"invalid between, one number", Rule{Target: "body", Modifier: ".age", Value: "18", Operator: "between"}, true},
+		{"invalid between, minimum greater than maximum", Rule{Target: "body", Modifier: ".age", Value: "65,18", Operator: "between"}, true},
+		{"invalid regex", Rule{Target: "body", Value: "^[a-z+", Operator: "regex"}, true},
+		{"invalid operator", Rule{Target: "body", Value: "joe", Operator: "like"}, true},
+		{"valid JSON contains rule", Rule{Target: "body", Value: `{"name": "joe"}`, Operator: "json_contains", IgnoreArrayOrder: true}, false},
+		{"invalid JSON contains rule", Rule{Target: "body", Value: `{"name": `, Operator: "json_contains"}, true},
+		{"valid JSON schema file rule", Rule{Target: "body", Value: "user.schema.json", Operator: "json_schema"}, false},
+		{"invalid inline JSON schema rule", Rule{Target: "body", Value: `{"type": `, Operator: "json_schema"}, true},
+		{"valid form field rule", Rule{Target: "form_field", Modifier: "name", Value: "joe", Operator: "equal"}, false},
+		{"invalid form field rule, missing field name", Rule{Target: "form_field", Value: "joe", Operator: "equal"}, true},
+		{"valid multipart rule", Rule{Target: "multipart_filename", Modifier: "avatar", Value: ".png", Operator: "ends_with"}, false},
+		{"valid XPath rule", Rule{Target: "xpath", Modifier: "count(//item)", Value: "2", Operator: "equal"}, false},
+		{"invalid XPath rule", Rule{Target: "xpath", Modifier: "//item[", Value: "2", Operator: "equal"}, true},
+		{"valid CIDR rule", Rule{Target: "remote_ip", Value: "10.0.0.0/8, ::1, 192.168.1.1", Operator: "cidr"}, false},
+		{"invalid CIDR rule", Rule{Target: "remote_ip", Value: "10.0.0.0/33", Operator: "cidr"}, true},
+		{"invalid CIDR rule with values", Rule{Target: "remote_ip", Values: []string{"10.0.0.0/8", "10.0.0.0/33"}, Operator: "cidr"}, true},
+		{"valid method rule", Rule{Target: "method", Value: "GET,POST", Operator: "in"}, false},
+		{"valid client cert SAN rule", Rule{Target: "client_cert_san", Modifier: "dns", Value: "billing.internal", Operator: "equal"}, false},
+		{"invalid client cert SAN type", Rule{Target: "client_cert_san", Modifier: "phone", Value: "billing.internal", Operator: "equal"}, true},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// RuleGroup is either a rule, or a group of rule groups, e.g. (A and B) or not C:
+//
+//	any:
+//	  - all: [A, B]
+//	  - none: [C]
+//
+// A group with several of all, any and none matches if each of them matches.
+type RuleGroup struct {
+	Rule `yaml:",inline"`
+	// All matches if all the groups match
+	All []RuleGroup `yaml:"all,omitempty" json:"all,omitempty"`
+	// Any matches if one of the groups matches
+	Any []RuleGroup `yaml:"any,omitempty" json:"any,omitempty"`
+	// None matches if none of the groups matches
+	None []RuleGroup `yaml:"none,omitempty" json:"none,omitempty"`
+}
+
+// IsRule returns true if the group is a single rule
+func (g RuleGroup) IsRule() bool {
+	return g.Target != ""
+}
+
+// groups is a rule group without the rule, so groups are written without empty rule fields
+type groups struct {
+	All  []RuleGroup `yaml:"all,omitempty" json:"all,omitempty"`
+	Any  []RuleGroup `yaml:"any,omitempty" json:"any,omitempty"`
+	None []RuleGroup `yaml:"none,omitempty" json:"none,omitempty"`
+}
+
+func (g RuleGroup) MarshalYAML() (interface{}, error) {
+	if g.IsRule() {
+		return g.Rule, nil
+	}
+
+	return groups{All: g.All, Any: g.Any, None: g.None}, nil
+}
+
+func (g RuleGroup) MarshalJSON() ([]byte, error) {
+	if g.IsRule() {
+		return json.Marshal(g.Rule)
+	}
+
+	return json.Marshal(groups{All: g.All, Any: g.Any, None: g.None})
+}
+
+func (g RuleGroup) Validate() error {
+	hasGroups := len(g.All) > 0 || len(g.Any) > 0 || len(g.None) > 0
+
+	if g.IsRule() {
+		if hasGroups {
+			return errors.New("a rule can't have all, any or none groups")
+		}
+		return g.Rule.Validate()
+	}
+
+	if !hasGroups {
+		return errors.New("a rule or one of all, any and none is required")
+	}
+
+	return validation.ValidateStruct(
+		&g,
+		validation.Field(&g.All),
+		validation.Field(&g.Any),
+		validation.Field(&g.None),
+	)
+}
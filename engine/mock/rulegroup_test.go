@@ -0,0 +1,73 @@
+package mock_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestRuleGroup_Validate(t *testing.T) {
+	admin := RuleGroup{Rule: Rule{Target: Header, Modifier: "X-Role", Operator: Equal, Value: "admin"}}
+
+	tests := []struct {
+		name    string
+		group   RuleGroup
+		isValid bool
+	}{
+		{"rule", admin, true},
+		{"nested groups", RuleGroup{Any: []RuleGroup{{All: []RuleGroup{admin, admin}}, {None: []RuleGroup{admin}}}}, true},
+		{"empty group", RuleGroup{}, false},
+		{"rule with groups", RuleGroup{Rule: admin.Rule, All: []RuleGroup{admin}}, false},
+		{"invalid rule", RuleGroup{Rule: Rule{Target: Header, Operator: "like", Value: "admin"}}, false},
+		{"invalid nested rule", RuleGroup{All: []RuleGroup{{None: []RuleGroup{{Rule: Rule{Target: "random", Operator: Equal, Value: "1"}}}}}}, false},
+		{"empty nested group", RuleGroup{Any: []RuleGroup{admin, {}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestRuleGroup_Marshal(t *testing.T) {
+	text := `any:
+- all:
+  - target: header
+    modifier: X-Role
+    value: admin
+    operator: equal
+  - target: query_string
+    modifier: debug
+    value: "true"
+    operator: equal
+- none:
+  - target: cookie
+    modifier: session
+    value: ""
+    operator: exists
+`
+
+	var group RuleGroup
+	require.NoError(t, yaml.Unmarshal([]byte(text), &group))
+	require.Len(t, group.Any, 2)
+	assert.Equal(t, Rule{Target: Header, Modifier: "X-Role", Value: "admin", Operator: Equal}, group.Any[0].All[0].Rule)
+	assert.Equal(t, Exists, group.Any[1].None[0].Operator)
+
+	out, err := yaml.Marshal(group)
+	require.NoError(t, err)
+	assert.Equal(t, text, string(out))
+
+	data, err := json.Marshal(group)
+	require.NoError(t, err)
+	var fromJSON RuleGroup
+	require.NoError(t, json.Unmarshal(data, &fromJSON))
+	assert.Equal(t, group, fromJSON)
+	assert.NotContains(t, string(data), `"target":""`)
+}
@@ -0,0 +1,86 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// SSE streams the events as server-sent events instead of writing the body
+type SSE struct {
+	Events []Event `yaml:"events" json:"events"`
+	// Repeat sends the events again the given number of times
+	Repeat int `yaml:"repeat,omitempty" json:"repeat,omitempty"`
+	// Loop sends the events again until the client disconnects, one of the events must have a delay
+	Loop bool `yaml:"loop,omitempty" json:"loop,omitempty"`
+}
+
+func (s SSE) Validate() error {
+	return validation.ValidateStruct(
+		&s,
+		validation.Field(&s.Events, validation.Required),
+		validation.Field(&s.Repeat, validation.Min(0)),
+		validation.Field(&s.Loop, validation.When(s.Loop, validation.By(s.validateLoop))),
+	)
+}
+
+// validateLoop rejects loops without delays, they would write events as fast as possible
+func (s SSE) validateLoop(_ interface{}) error {
+	for _, event := range s.Events {
+		if event.Delay > 0 {
+			return nil
+		}
+	}
+
+	return errors.New("requires an event with a delay")
+}
+
+// Event is a server-sent event, data supports faker, and templates if enabled
+type Event struct {
+	Event string `yaml:"event,omitempty" json:"event,omitempty"`
+	Data  string `yaml:"data,omitempty" json:"data,omitempty"`
+	ID    string `yaml:"id,omitempty" json:"id,omitempty"`
+	// Retry is the reconnection time sent to the client, in milliseconds
+	Retry int `yaml:"retry,omitempty" json:"retry,omitempty"`
+	// Delay is the wait before sending the event, in milliseconds
+	Delay int `yaml:"delay,omitempty" json:"delay,omitempty"`
+}
+
+func (e Event) Validate() error {
+	return validation.ValidateStruct(
+		&e,
+		validation.Field(&e.Event, validation.By(singleLine)),
+		validation.Field(&e.ID, validation.By(singleLine)),
+		validation.Field(&e.Retry, validation.Min(0)),
+		validation.Field(&e.Delay, validation.Min(0)),
+	)
+}
+
+// String returns the event in the text/event-stream format, multi-line data is sent as several data fields
+func (e Event) String() string {
+	var sb strings.Builder
+	if e.ID != "" {
+		sb.WriteString(fmt.Sprintf("id: %s\n", e.ID))
+	}
+	if e.Event != "" {
+		sb.WriteString(fmt.Sprintf("event: %s\n", e.Event))
+	}
+	if e.Retry > 0 {
+		sb.WriteString(fmt.Sprintf("retry: %d\n", e.Retry))
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(e.Data, "\r\n", "\n"), "\n") {
+		sb.WriteString(fmt.Sprintf("data: %s\n", line))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func singleLine(value interface{}) error {
+	if s, ok := value.(string); ok && strings.ContainsAny(s, "\r\n") {
+		return errors.New("must not contain line breaks")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestSSE_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sse     SSE
+		isValid bool
+	}{
+		{"valid", SSE{Events: []Event{{Data: "hello"}}, Repeat: 2}, true},
+		{"loop", SSE{Events: []Event{{Data: "hello"}, {Data: "world", Delay: 1000}}, Loop: true}, true},
+		{"loop without delay", SSE{Events: []Event{{Data: "hello"}}, Loop: true}, false},
+		{"no events", SSE{}, false},
+		{"invalid repeat", SSE{Events: []Event{{Data: "hello"}}, Repeat: -1}, false},
+		{"invalid delay", SSE{Events: []Event{{Data: "hello", Delay: -1}}}, false},
+		{"multi-line event name", SSE{Events: []Event{{Event: "a\nb"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sse.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestEvent_String(t *testing.T) {
+	assert.Equal(t, "data: hello\n\n", Event{Data: "hello"}.String())
+	assert.Equal(t, "id: 1\nevent: update\nretry: 3000\ndata: line 1\ndata: line 2\n\n", Event{
+		ID:    "1",
+		Event: "update",
+		Retry: 3000,
+		Data:  "line 1\nline 2",
+	}.String())
+}
@@ -0,0 +1,74 @@
+package mock
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// chunksPerSecond is the number of chunks written per second when only the rate is set
+const chunksPerSecond = 10
+
+// Throttle slows down the response to simulate slow networks
+type Throttle struct {
+	// TimeToFirstByte is the wait before writing the headers, in milliseconds
+	TimeToFirstByte int `yaml:"time_to_first_byte,omitempty" json:"time_to_first_byte,omitempty"`
+	// BytesPerSecond caps the transfer rate of the body
+	BytesPerSecond int `yaml:"bytes_per_second,omitempty" json:"bytes_per_second,omitempty"`
+	// ChunkSize splits the body into chunks of the given size in bytes
+	ChunkSize int `yaml:"chunk_size,omitempty" json:"chunk_size,omitempty"`
+	// ChunkDelay is the wait between chunks, in milliseconds
+	ChunkDelay int `yaml:"chunk_delay,omitempty" json:"chunk_delay,omitempty"`
+	// Duration is the total time to transfer the body in milliseconds, the chunks are spread evenly
+	Duration int `yaml:"duration,omitempty" json:"duration,omitempty"`
+}
+
+func (t Throttle) Validate() error {
+	return validation.ValidateStruct(
+		&t,
+		validation.Field(&t.TimeToFirstByte, validation.Min(0)),
+		validation.Field(&t.BytesPerSecond, validation.Min(0)),
+		validation.Field(&t.ChunkSize, validation.Min(0)),
+		validation.Field(&t.ChunkDelay, validation.Min(0)),
+		validation.Field(&t.Duration, validation.Min(0)),
+	)
+}
+
+// Chunks returns the chunk size and the wait between chunks for a body of the given size
+func (t Throttle) Chunks(size int64) (int64, time.Duration) {
+	chunkSize := int64(t.ChunkSize)
+	if chunkSize == 0 {
+		switch {
+		case t.BytesPerSecond > 0:
+			chunkSize = int64(t.BytesPerSecond / chunksPerSecond)
+		case t.Duration > 0:
+			chunkSize = size / chunksPerSecond
+		default:
+			chunkSize = size
+		}
+	}
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	var wait time.Duration
+	switch {
+	case t.ChunkDelay > 0:
+		wait = time.Duration(t.ChunkDelay) * time.Millisecond
+	case t.Duration > 0:
+		chunks := (size + chunkSize - 1) / chunkSize
+		if chunks > 1 {
+			wait = time.Duration(t.Duration) * time.Millisecond / time.Duration(chunks-1)
+		}
+	}
+
+	// the rate is a cap, a slower chunk delay or duration is kept
+	if t.BytesPerSecond > 0 {
+		rateWait := time.Second * time.Duration(chunkSize) / time.Duration(t.BytesPerSecond)
+		if rateWait > wait {
+			wait = rateWait
+		}
+	}
+
+	return chunkSize, wait
+}
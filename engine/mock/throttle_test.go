@@ -0,0 +1,42 @@
+package mock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestThrottle_Validate(t *testing.T) {
+	assert.NoError(t, Throttle{TimeToFirstByte: 100, BytesPerSecond: 1024}.Validate())
+	assert.Error(t, Throttle{BytesPerSecond: -1}.Validate())
+	assert.Error(t, Throttle{ChunkDelay: -1}.Validate())
+}
+
+func TestThrottle_Chunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		throttle  Throttle
+		size      int64
+		chunkSize int64
+		wait      time.Duration
+	}{
+		{"no throttle", Throttle{}, 100, 100, 0},
+		{"rate only", Throttle{BytesPerSecond: 1000}, 1000, 100, 100 * time.Millisecond},
+		{"chunk delay", Throttle{ChunkSize: 10, ChunkDelay: 50}, 100, 10, 50 * time.Millisecond},
+		{"duration", Throttle{Duration: 900}, 100, 10, 100 * time.Millisecond},
+		{"rate caps chunk delay", Throttle{ChunkSize: 100, ChunkDelay: 10, BytesPerSecond: 1000}, 1000, 100, 100 * time.Millisecond},
+		{"slower chunk delay is kept", Throttle{ChunkSize: 100, ChunkDelay: 500, BytesPerSecond: 1000}, 1000, 100, 500 * time.Millisecond},
+		{"tiny rate", Throttle{BytesPerSecond: 5}, 10, 1, 200 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunkSize, wait := tt.throttle.Chunks(tt.size)
+			assert.Equal(t, tt.chunkSize, chunkSize)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+}
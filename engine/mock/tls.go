@@ -1,7 +1,35 @@
 package mock
 
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type ClientAuth string
+
+const (
+	// ClientAuthRequest asks for a client certificate, the certificate is verified if the client CA is set
+	ClientAuthRequest ClientAuth = "request"
+	// ClientAuthRequire requires a client certificate, the certificate is not verified
+	ClientAuthRequire ClientAuth = "require"
+	// ClientAuthVerify requires a client certificate signed by the client CA
+	ClientAuthVerify ClientAuth = "verify"
+)
+
 type TLS struct {
 	Enabled     bool   `yaml:"enabled,omitempty" json:"enabled,omitempty"`
 	PEMCertPath string `yaml:"pem_cert_path,omitempty" json:"pem_cert_path,omitempty"`
 	PEMKeyPath  string `yaml:"pem_key_path,omitempty" json:"pem_key_path,omitempty"`
+	// ClientCAPath is the PEM bundle of the CAs verifying the client certificates,
+	// relative paths are relative to the mock file
+	ClientCAPath string `yaml:"client_ca_path,omitempty" json:"client_ca_path,omitempty"`
+	// ClientAuth is the client certificate mode for mutual TLS, client certificates are not asked by default
+	ClientAuth ClientAuth `yaml:"client_auth,omitempty" json:"client_auth,omitempty"`
+}
+
+func (t TLS) Validate() error {
+	return validation.ValidateStruct(
+		&t,
+		validation.Field(&t.ClientAuth, validation.In(ClientAuthRequest, ClientAuthRequire, ClientAuthVerify)),
+		validation.Field(&t.ClientCAPath, validation.When(t.ClientAuth == ClientAuthVerify, validation.Required)),
+	)
 }
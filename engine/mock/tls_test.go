@@ -0,0 +1,31 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestTLS_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tls     TLS
+		isValid bool
+	}{
+		{"no client auth", TLS{Enabled: true}, true},
+		{"request client cert", TLS{Enabled: true, ClientAuth: ClientAuthRequest}, true},
+		{"require client cert", TLS{Enabled: true, ClientAuth: ClientAuthRequire}, true},
+		{"verify client cert", TLS{Enabled: true, ClientAuth: ClientAuthVerify, ClientCAPath: "ca.pem"}, true},
+		{"verify client cert without CA", TLS{Enabled: true, ClientAuth: ClientAuthVerify}, false},
+		{"invalid client auth", TLS{Enabled: true, ClientAuth: "always"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tls.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
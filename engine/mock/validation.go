@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validation checks the incoming requests against an OpenAPI document before the routes are matched
+type Validation struct {
+	// OpenAPI is the OpenAPI document file, relative paths are relative to the mock file
+	OpenAPI string `yaml:"openapi,omitempty" json:"openapi,omitempty"`
+	// Status of the response to invalid requests, 400 by default
+	Status int `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+func (v Validation) Validate() error {
+	return validation.ValidateStruct(
+		&v,
+		validation.Field(&v.OpenAPI, validation.Required),
+		validation.Field(&v.Status, validation.Min(100), validation.Max(599)),
+	)
+}
+
+// StatusCode returns the status of the response to invalid requests
+func (v Validation) StatusCode() int {
+	if v.Status == 0 {
+		return http.StatusBadRequest
+	}
+
+	return v.Status
+}
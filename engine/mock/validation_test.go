@@ -0,0 +1,34 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestValidation_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation Validation
+		isValid    bool
+	}{
+		{"valid", Validation{OpenAPI: "openapi.yaml"}, true},
+		{"valid status", Validation{OpenAPI: "openapi.yaml", Status: 422}, true},
+		{"missing document", Validation{}, false},
+		{"invalid status", Validation{OpenAPI: "openapi.yaml", Status: 1000}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validation.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestValidation_StatusCode(t *testing.T) {
+	assert.Equal(t, 400, Validation{}.StatusCode())
+	assert.Equal(t, 422, Validation{Status: 422}.StatusCode())
+}
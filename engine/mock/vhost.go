@@ -0,0 +1,53 @@
+package mock
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+var (
+	hostRegex       = regexp.MustCompile(`^[a-zA-Z0-9*]([a-zA-Z0-9*.-]*[a-zA-Z0-9*])?$`)
+	pathPrefixRegex = regexp.MustCompile(`^/\S*$`)
+)
+
+// MatchHost reports whether the request host, with or without port, is one of the hosts of the mock.
+// Mocks without hosts match all hosts.
+func (m Mock) MatchHost(host string) bool {
+	if len(m.Hosts) == 0 {
+		return true
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	for _, pattern := range m.Hosts {
+		if wildcard.Match(strings.ToLower(pattern), strings.ToLower(host)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TrimPathPrefix removes the path prefix of the mock from the request path.
+// It returns false if the path doesn't start with the prefix.
+func (m Mock) TrimPathPrefix(path string) (string, bool) {
+	prefix := strings.TrimRight(m.PathPrefix, "/")
+	if prefix == "" {
+		return path, true
+	}
+
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return "", false
+	}
+
+	if trimmed := strings.TrimPrefix(path, prefix); trimmed != "" {
+		return trimmed, true
+	}
+
+	return "/", true
+}
@@ -0,0 +1,110 @@
+package mock
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// WebSocket turns the route into a WebSocket endpoint, the route responses are not used
+type WebSocket struct {
+	// OnConnect are the messages sent when the client connects
+	OnConnect []WebSocketMessage `yaml:"on_connect,omitempty" json:"on_connect,omitempty"`
+	// Replies are sent when a client message matches the rules, the first matching reply is used
+	Replies []Reply `yaml:"replies,omitempty" json:"replies,omitempty"`
+	// Pushes are messages sent on a schedule after the client connects
+	Pushes []Push `yaml:"pushes,omitempty" json:"pushes,omitempty"`
+	// Close closes the connection after a delay
+	Close *Close `yaml:"close,omitempty" json:"close,omitempty"`
+	// Template renders the messages as Go templates with the request data and the received message
+	Template bool `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+func (w WebSocket) Validate() error {
+	return validation.ValidateStruct(
+		&w,
+		validation.Field(&w.OnConnect),
+		validation.Field(&w.Replies),
+		validation.Field(&w.Pushes),
+		validation.Field(&w.Close),
+	)
+}
+
+// WebSocketMessage is a text message, data supports faker, and templates if enabled
+type WebSocketMessage struct {
+	Data string `yaml:"data" json:"data"`
+	// Delay is the wait before sending the message, in milliseconds
+	Delay int `yaml:"delay,omitempty" json:"delay,omitempty"`
+}
+
+func (m WebSocketMessage) Validate() error {
+	return validation.ValidateStruct(
+		&m,
+		validation.Field(&m.Delay, validation.Min(0)),
+	)
+}
+
+// Reply answers the client messages matching the rules, the message target is the received text
+// and its modifier is a gojq query for JSON messages
+type Reply struct {
+	RuleAggregation RuleAggregation    `yaml:"rule_aggregation,omitempty" json:"rule_aggregation,omitempty"`
+	Rules           []Rule             `yaml:"rules,omitempty" json:"rules,omitempty"`
+	Messages        []WebSocketMessage `yaml:"messages,omitempty" json:"messages,omitempty"`
+	// Close closes the connection after sending the messages
+	Close *Close `yaml:"close,omitempty" json:"close,omitempty"`
+}
+
+func (r Reply) Validate() error {
+	return validation.ValidateStruct(
+		&r,
+		validation.Field(&r.RuleAggregation, validation.In(Or, And)),
+		validation.Field(&r.Rules, validation.Each(validation.By(func(value interface{}) error {
+			if rule, ok := value.(Rule); ok && rule.Target == RequestNumber {
+				return errors.New("request_number target is not supported")
+			}
+			return nil
+		}))),
+		validation.Field(&r.Messages),
+		validation.Field(&r.Close),
+	)
+}
+
+// Push is a message sent after a delay, then every interval if set, both in milliseconds
+type Push struct {
+	Data     string `yaml:"data" json:"data"`
+	Delay    int    `yaml:"delay,omitempty" json:"delay,omitempty"`
+	Interval int    `yaml:"interval,omitempty" json:"interval,omitempty"`
+}
+
+func (p Push) Validate() error {
+	return validation.ValidateStruct(
+		&p,
+		validation.Field(&p.Delay, validation.Min(0)),
+		validation.Field(&p.Interval, validation.Min(0)),
+	)
+}
+
+// Close is the close frame sent to the client, the code defaults to 1000 (normal closure)
+type Close struct {
+	Code   int    `yaml:"code,omitempty" json:"code,omitempty"`
+	Reason string `yaml:"reason,omitempty" json:"reason,omitempty"`
+	// Delay is the wait before closing the connection, in milliseconds
+	Delay int `yaml:"delay,omitempty" json:"delay,omitempty"`
+}
+
+func (c Close) Validate() error {
+	return validation.ValidateStruct(
+		&c,
+		validation.Field(&c.Code, validation.When(c.Code != 0, validation.Min(1000), validation.Max(4999))),
+		validation.Field(&c.Reason, validation.Length(0, 123)),
+		validation.Field(&c.Delay, validation.Min(0)),
+	)
+}
+
+// StatusCode returns the close code, 1000 if not set
+func (c Close) StatusCode() int {
+	if c.Code == 0 {
+		return 1000
+	}
+	return c.Code
+}
@@ -0,0 +1,44 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestWebSocket_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ws      WebSocket
+		isValid bool
+	}{
+		{"valid", WebSocket{
+			OnConnect: []WebSocketMessage{{Data: "hello"}},
+			Replies: []Reply{{
+				Rules:    []Rule{{Target: Message, Modifier: ".type", Operator: Equal, Value: "ping"}},
+				Messages: []WebSocketMessage{{Data: "pong"}},
+			}},
+			Pushes: []Push{{Data: "tick", Interval: 1000}},
+			Close:  &Close{Code: 4000, Delay: 5000},
+		}, true},
+		{"invalid message delay", WebSocket{OnConnect: []WebSocketMessage{{Data: "hello", Delay: -1}}}, false},
+		{"invalid reply rule", WebSocket{Replies: []Reply{{Rules: []Rule{{Target: RequestNumber, Operator: Equal, Value: "1"}}}}}, false},
+		{"invalid reply rule value", WebSocket{Replies: []Reply{{Rules: []Rule{{Target: Message, Operator: Regex, Value: "("}}}}}, false},
+		{"invalid push interval", WebSocket{Pushes: []Push{{Interval: -1}}}, false},
+		{"invalid close code", WebSocket{Close: &Close{Code: 999}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ws.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestClose_StatusCode(t *testing.T) {
+	assert.Equal(t, 1000, Close{}.StatusCode())
+	assert.Equal(t, 4001, Close{Code: 4001}.StatusCode())
+}
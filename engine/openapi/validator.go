@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/pkg/errors"
+)
+
+// FieldError is a part of a request that does not conform to the OpenAPI document
+type FieldError struct {
+	// In is where the field is: path, query, header, cookie or body
+	In string `json:"in"`
+	// Field is the parameter name, or the JSON pointer of a body field, e.g. /pets/0/name
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Path returns the location and the name of the field, e.g. query.limit
+func (e FieldError) Path() string {
+	if e.Field == "" {
+		return e.In
+	}
+
+	return e.In + "." + e.Field
+}
+
+// Validator validates requests against an OpenAPI document
+type Validator struct {
+	router routers.Router
+}
+
+// NewValidator loads an OpenAPI document, references to other files are resolved
+func NewValidator(file string) (*Validator, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	doc, err := loader.LoadFromFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "load OpenAPI document")
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, errors.Wrap(err, "invalid OpenAPI document")
+	}
+
+	if err := relativeServers(doc); err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "create OpenAPI router")
+	}
+
+	return &Validator{router: router}, nil
+}
+
+// Validate returns the fields of the request not conforming to the document.
+// Requests to operations not in the document are not validated.
+func (v *Validator) Validate(req *http.Request) []FieldError {
+	route, pathParams, err := v.router.FindRoute(req)
+	if err != nil {
+		return nil
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		defer func() {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}()
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+
+	err = openapi3filter.ValidateRequest(req.Context(), input)
+	if err == nil {
+		return nil
+	}
+
+	return fieldErrors(err, FieldError{})
+}
+
+// fieldErrors flattens the validation errors, field is the location known so far
+func fieldErrors(err error, field FieldError) []FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fieldErrs []FieldError
+		for _, err := range e {
+			fieldErrs = append(fieldErrs, fieldErrors(err, field)...)
+		}
+		return fieldErrs
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			field.In = e.Parameter.In
+			field.Field = e.Parameter.Name
+		case e.RequestBody != nil:
+			field.In = "body"
+		}
+
+		if e.Err == nil {
+			field.Message = e.Reason
+			return []FieldError{field}
+		}
+		return fieldErrors(e.Err, field)
+	case *openapi3.SchemaError:
+		if pointer := e.JSONPointer(); field.In == "body" && len(pointer) > 0 {
+			field.Field = "/" + strings.Join(pointer, "/")
+		}
+		field.Message = e.Reason
+		return []FieldError{field}
+	}
+
+	field.Message = err.Error()
+	return []FieldError{field}
+}
+
+// relativeServers keeps only the base paths of the servers, so requests are matched whatever the mock host is
+func relativeServers(doc *openapi3.T) error {
+	servers := func(servers openapi3.Servers) (openapi3.Servers, error) {
+		relative := make(openapi3.Servers, 0, len(servers))
+		for _, server := range servers {
+			basePath, err := server.BasePath()
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse server %v", server.URL)
+			}
+			relative = append(relative, &openapi3.Server{URL: strings.TrimRight(basePath, "/")})
+		}
+		return relative, nil
+	}
+
+	var err error
+	if doc.Servers, err = servers(doc.Servers); err != nil {
+		return err
+	}
+
+	for _, pathItem := range doc.Paths {
+		if pathItem.Servers, err = servers(pathItem.Servers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
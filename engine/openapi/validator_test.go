@@ -0,0 +1,108 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/mockingio/mockingio/engine/openapi"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	validator, err := NewValidator("fixtures/petstore.yaml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		errors  []FieldError
+	}{
+		{
+			"valid query",
+			func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/v1/pets?limit=10", nil)
+			},
+			nil,
+		},
+		{
+			"invalid query",
+			func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/v1/pets?limit=1000", nil)
+			},
+			[]FieldError{{In: "query", Field: "limit", Message: "number must be at most 100"}},
+		},
+		{
+			"invalid path param",
+			func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/v1/pets/rex", nil)
+			},
+			[]FieldError{{In: "path", Field: "petId", Message: "value rex: an invalid integer: invalid syntax"}},
+		},
+		{
+			"valid body",
+			func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(`{"name": "Rex"}`))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-Request-ID", "1")
+				return req
+			},
+			nil,
+		},
+		{
+			"invalid body and missing header",
+			func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(`{"tags": [1]}`))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			[]FieldError{
+				{In: "header", Field: "X-Request-ID", Message: "value is required but missing"},
+				{In: "body", Field: "/name", Message: `property "name" is missing`},
+				{In: "body", Field: "/tags/0", Message: "field must be set to string or not be present"},
+			},
+		},
+		{
+			"not in the document",
+			func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/v1/owners", nil)
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.errors, validator.Validate(tt.request()))
+		})
+	}
+}
+
+func TestValidator_Validate_KeepsBody(t *testing.T) {
+	validator, err := NewValidator("fixtures/petstore.yaml")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(`{"name": "Rex"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "1")
+	assert.Empty(t, validator.Validate(req))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name": "Rex"}`, string(body))
+}
+
+func TestNewValidator_Error(t *testing.T) {
+	_, err := NewValidator("fixtures/not-found.yaml")
+	assert.Error(t, err)
+}
+
+func TestFieldError_Path(t *testing.T) {
+	assert.Equal(t, "query.limit", FieldError{In: "query", Field: "limit"}.Path())
+	assert.Equal(t, "body./name", FieldError{In: "body", Field: "/name"}.Path())
+	assert.Equal(t, "body", FieldError{In: "body"}.Path())
+}
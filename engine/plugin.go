@@ -1,7 +1,10 @@
 package engine
 
-import "github.com/mockingio/mockingio/engine/mock"
+import (
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
 
 type Plugin interface {
-	Response(response *mock.Response)
+	Response(req matcher.Context, route *mock.Route, response *mock.Response)
 }
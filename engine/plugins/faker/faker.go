@@ -6,6 +6,7 @@ import (
 
 	"github.com/jaswdr/faker"
 
+	"github.com/mockingio/mockingio/engine/matcher"
 	"github.com/mockingio/mockingio/engine/mock"
 )
 
@@ -15,7 +16,7 @@ func New() *Faker {
 
 type Faker struct{}
 
-func (f *Faker) Response(response *mock.Response) {
+func (f *Faker) Response(_ matcher.Context, _ *mock.Route, response *mock.Response) {
 	apply := applier(faker.New())
 
 	response.Body = apply(response.Body)
@@ -50,7 +51,7 @@ func runCmd(obj any, com command) string {
 			}
 
 			if next == nil {
-				return m.Func.Call(append([]reflect.Value{reflect.ValueOf(obj)}))[0].String()
+				return m.Func.Call([]reflect.Value{reflect.ValueOf(obj)})[0].String()
 			}
 
 			if args, ok := next.([]any); ok {
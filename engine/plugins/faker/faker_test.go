@@ -3,6 +3,7 @@ package faker
 import (
 	"testing"
 
+	"github.com/mockingio/mockingio/engine/matcher"
 	"github.com/mockingio/mockingio/engine/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,7 +84,7 @@ func TestFaker_Response(t *testing.T) {
 			"X-Test": "no commands",
 		},
 	}
-	plug.Response(resp)
+	plug.Response(matcher.Context{}, &mock.Route{}, resp)
 
 	assert.NotEqual(t, "hi ${faker.person.name}", resp.Body)
 	assert.NotEqual(t, map[string]string{
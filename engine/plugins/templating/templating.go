@@ -0,0 +1,156 @@
+package templating
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+const (
+	openDelim = "{{"
+)
+
+func New() *Templating {
+	return &Templating{}
+}
+
+// Templating renders the body and headers of the responses with template enabled as Go templates,
+// with access to the request data. Values written in JSON are encoded with json, quotes included.
+//
+//	{"id": {{ json (param "id") }}, "page": {{ json (query "page") }}, "name": {{ json (body ".user.name") }}}
+type Templating struct{}
+
+// data is the root object of the template, e.g. {{ .Method }}
+type data struct {
+	Method string
+	Path   string
+	Query  string
+	Host   string
+}
+
+func (t *Templating) Response(req matcher.Context, route *mock.Route, response *mock.Response) {
+	if req.HTTPRequest == nil || !response.Template {
+		return
+	}
+
+	apply := applier(req, route)
+
+	response.Body = apply(response.Body)
+	for k, v := range response.Headers {
+		response.Headers[k] = apply(v)
+	}
+}
+
+func applier(req matcher.Context, route *mock.Route) func(string) string {
+	httpRequest := req.HTTPRequest
+	funcs := funcMap(req, route)
+	root := data{
+		Method: httpRequest.Method,
+		Path:   httpRequest.URL.Path,
+		Query:  httpRequest.URL.RawQuery,
+		Host:   httpRequest.Host,
+	}
+
+	return func(input string) string {
+		if !strings.Contains(input, openDelim) {
+			return input
+		}
+
+		tmpl, err := template.New("response").Funcs(funcs).Parse(input)
+		if err != nil {
+			log.WithError(err).Debug("parse response template")
+			return input
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, root); err != nil {
+			log.WithError(err).Debug("execute response template")
+			return input
+		}
+
+		return buf.String()
+	}
+}
+
+func funcMap(req matcher.Context, route *mock.Route) template.FuncMap {
+	httpRequest := req.HTTPRequest
+
+	return template.FuncMap{
+		"param": func(name string) string {
+			if route == nil {
+				return ""
+			}
+			return matcher.RouteParams(route.Path, httpRequest.URL.Path)[name]
+		},
+		"query": func(name string) string {
+			return httpRequest.URL.Query().Get(name)
+		},
+		"header": func(name string) string {
+			return httpRequest.Header.Get(name)
+		},
+		"cookie": func(name string) string {
+			c, err := httpRequest.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return c.Value
+		},
+		// body returns the raw body, or the value of the gojq query, e.g. {{ body ".user.id" }}
+		"body": func(query ...string) (string, error) {
+			value, err := matcher.ReadBody(httpRequest)
+			if err != nil {
+				return "", err
+			}
+
+			if len(query) == 0 || query[0] == "" || len(value) == 0 {
+				return string(value), nil
+			}
+
+			return matcher.QueryJSON(value, query[0])
+		},
+		// message returns the WebSocket message, or the value of the gojq query, e.g. {{ message ".id" }}
+		"message": func(query ...string) (string, error) {
+			if len(query) == 0 || query[0] == "" || req.Message == "" {
+				return req.Message, nil
+			}
+
+			return matcher.QueryJSON([]byte(req.Message), query[0])
+		},
+		// now returns the current time, formatted with the layout if given, RFC3339 otherwise
+		"now": func(layout ...string) string {
+			if len(layout) == 0 {
+				return time.Now().Format(time.RFC3339)
+			}
+			return time.Now().Format(layout[0])
+		},
+		"uuid": func() string {
+			return uuid.NewString()
+		},
+		// json encodes the value as JSON, so request values are escaped in JSON bodies
+		"json": func(value any) (string, error) {
+			var buf bytes.Buffer
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+			if err := encoder.Encode(value); err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(buf.String(), "\n"), nil
+		},
+		"base64": func(value string) string {
+			return base64.StdEncoding.EncodeToString([]byte(value))
+		},
+		"base64Decode": func(value string) (string, error) {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			return string(decoded), err
+		},
+	}
+}
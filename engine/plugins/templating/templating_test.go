@@ -0,0 +1,90 @@
+package templating
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestTemplating_Response(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users/10?page=2", strings.NewReader(`{"user": {"name": "joe", "age": 20}}`))
+	req.Header.Set("X-Request-ID", "abc")
+	req.Header.Set("X-Name", `joe "<jo>"`)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "xyz"})
+	route := &mock.Route{Path: "/users/:id"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"no template", `{"id": 1}`, `{"id": 1}`},
+		{"route param", `{"id": "{{ param "id" }}"}`, `{"id": "10"}`},
+		{"query string", `{{ query "page" }}`, "2"},
+		{"header", `{{ header "X-Request-ID" }}`, "abc"},
+		{"cookie", `{{ cookie "session" }}`, "xyz"},
+		{"missing cookie", `{{ cookie "missing" }}`, ""},
+		{"body", `{{ body }}`, `{"user": {"name": "joe", "age": 20}}`},
+		{"body query", `{{ body ".user.name" }}`, "joe"},
+		{"body query number", `{{ body ".user.age" }}`, "20"},
+		{"request data", `{{ .Method }} {{ .Path }} {{ .Query }}`, "POST /users/10 page=2"},
+		{"base64", `{{ base64 (param "id") }}`, "MTA="},
+		{"base64 decode", `{{ base64Decode "MTA=" }}`, "10"},
+		{"now with layout", `{{ now "2006" }}`, time.Now().Format("2006")},
+		{"json", `{"name": {{ json (header "X-Name") }}, "id": {{ json (param "id") }}}`, `{"name": "joe \"<jo>\"", "id": "10"}`},
+		{"invalid template", `{{ param "id" `, `{{ param "id" `},
+		{"unknown function", `{{ unknown }}`, `{{ unknown }}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &mock.Response{Body: tt.input, Template: true}
+			New().Response(matcher.Context{HTTPRequest: req}, route, resp)
+			assert.Equal(t, tt.expected, resp.Body)
+		})
+	}
+}
+
+func TestTemplating_Response_Disabled(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/10", nil)
+	resp := &mock.Response{
+		Body:    `Hello {{ name }}, {{ param "id" }}`,
+		Headers: map[string]string{"X-ID": `{{ param "id" }}`},
+	}
+
+	New().Response(matcher.Context{HTTPRequest: req}, &mock.Route{Path: "/users/:id"}, resp)
+
+	assert.Equal(t, `Hello {{ name }}, {{ param "id" }}`, resp.Body)
+	assert.Equal(t, `{{ param "id" }}`, resp.Headers["X-ID"])
+}
+
+func TestTemplating_Response_Headers(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/10", nil)
+	resp := &mock.Response{
+		Headers: map[string]string{
+			"X-ID":   `{{ param "id" }}`,
+			"X-UUID": `{{ uuid }}`,
+		},
+		Template: true,
+	}
+
+	New().Response(matcher.Context{HTTPRequest: req}, &mock.Route{Path: "/users/:id"}, resp)
+
+	assert.Equal(t, "10", resp.Headers["X-ID"])
+	assert.Len(t, resp.Headers["X-UUID"], 36)
+}
+
+func TestTemplating_Response_Message(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	resp := &mock.Response{Body: `{{ message ".id" }}: {{ message }}`, Template: true}
+
+	New().Response(matcher.Context{HTTPRequest: req, Message: `{"id": 1}`}, &mock.Route{Path: "/ws"}, resp)
+	assert.Equal(t, `1: {"id": 1}`, resp.Body)
+}
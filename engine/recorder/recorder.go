@@ -0,0 +1,286 @@
+package recorder
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// Redacted replaces the values of redacted headers and body fields
+const Redacted = "REDACTED"
+
+// skippedHeaders are response headers not saved, the engine or the HTTP server writes them
+var skippedHeaders = []string{
+	"Content-Length",
+	"Content-Encoding",
+	"Transfer-Encoding",
+	"Connection",
+	"Date",
+}
+
+// Exchange is a proxied request with the response from the upstream
+type Exchange struct {
+	Request      *http.Request
+	RequestBody  []byte
+	Response     *http.Response
+	ResponseBody []byte
+}
+
+// Record adds the exchange to the mock, as a new response of the route with the same method and path,
+// or as a new route. It returns false if the exchange is already recorded.
+func Record(mok *mock.Mock, cfg *mock.Record, exchange Exchange) bool {
+	if cfg == nil {
+		cfg = &mock.Record{}
+	}
+
+	response := toResponse(cfg, exchange)
+
+	for _, route := range mok.Routes {
+		if !strings.EqualFold(route.Method, exchange.Request.Method) || route.Path != exchange.Request.URL.Path {
+			continue
+		}
+
+		for _, existing := range route.Responses {
+			if cmp.Equal(withoutIDs(existing.Rules), withoutIDs(response.Rules)) {
+				return false
+			}
+		}
+
+		route.Responses = append(route.Responses, response)
+		return true
+	}
+
+	mok.Routes = append(mok.Routes, &mock.Route{
+		ID:        uuid.NewString(),
+		Method:    exchange.Request.Method,
+		Path:      exchange.Request.URL.Path,
+		Responses: []mock.Response{response},
+	})
+
+	return true
+}
+
+func toResponse(cfg *mock.Record, exchange Exchange) mock.Response {
+	response := mock.Response{
+		ID:      uuid.NewString(),
+		Status:  exchange.Response.StatusCode,
+		Headers: map[string]string{},
+		Body:    redactBody(cfg, exchange.ResponseBody),
+		Rules:   toRules(cfg, exchange),
+	}
+
+	for name, values := range exchange.Response.Header {
+		if len(values) == 0 || containsHeader(skippedHeaders, name) {
+			continue
+		}
+
+		value := values[0]
+		if containsHeader(cfg.RedactHeaders, name) {
+			value = Redacted
+		}
+		response.Headers[name] = value
+	}
+
+	if len(response.Rules) > 0 {
+		response.RuleAggregation = mock.And
+	}
+
+	return response
+}
+
+func toRules(cfg *mock.Record, exchange Exchange) []mock.Rule {
+	var rules []mock.Rule
+	req := exchange.Request
+
+	// requests without a matched header, query or body get a rule too, a response without rules would match
+	// all the requests of the route, and their variants would never be proxied again
+	for _, name := range cfg.MatchHeaders {
+		value := req.Header.Get(name)
+		switch {
+		case containsHeader(cfg.RedactHeaders, name):
+			continue
+		case value == "":
+			rules = append(rules, newRule(mock.Header, name, mock.Absent, ""))
+		default:
+			rules = append(rules, newRule(mock.Header, name, mock.Equal, value))
+		}
+	}
+
+	if cfg.MatchQueryStrings {
+		if req.URL.RawQuery == "" {
+			rules = append(rules, newRule(mock.RawQuery, "", mock.Absent, ""))
+		}
+
+		query := req.URL.Query()
+		keys := make([]string, 0, len(query))
+		for key := range query {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if value := query.Get(key); value != "" {
+				rules = append(rules, newRule(mock.QueryString, key, mock.Equal, value))
+			}
+		}
+	}
+
+	if cfg.MatchBody {
+		switch body := exchange.RequestBody; {
+		case len(body) == 0:
+			// GET and HEAD requests have no body
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				rules = append(rules, newRule(mock.Body, "", mock.Absent, ""))
+			}
+		case isRedacted(cfg, body):
+			// a redacted body would never match the request again, the other fields are matched
+			rules = append(rules, newRule(mock.Body, "", mock.JSONContains, withoutRedacted(cfg, body)))
+		default:
+			rules = append(rules, newRule(mock.Body, "", mock.Equal, redactBody(cfg, body)))
+		}
+	}
+
+	return rules
+}
+
+func newRule(target mock.Target, modifier string, operator mock.Operator, value string) mock.Rule {
+	return mock.Rule{
+		ID:       uuid.NewString(),
+		Target:   target,
+		Modifier: modifier,
+		Operator: operator,
+		Value:    value,
+	}
+}
+
+// redactBody replaces the redacted fields of JSON bodies, other bodies are kept as they are
+func redactBody(cfg *mock.Record, body []byte) string {
+	if len(cfg.RedactBodyFields) == 0 {
+		return string(body)
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(cfg.RedactBodyFields, value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+// isRedacted returns true if the body has redacted fields
+func isRedacted(cfg *mock.Record, body []byte) bool {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return false
+	}
+
+	return hasField(cfg.RedactBodyFields, value)
+}
+
+// withoutRedacted returns the JSON body without its redacted fields
+func withoutRedacted(cfg *mock.Record, body []byte) string {
+	var value any
+	_ = json.Unmarshal(body, &value)
+
+	data, err := json.Marshal(removeFields(cfg.RedactBodyFields, value))
+	if err != nil {
+		return "{}"
+	}
+
+	return string(data)
+}
+
+func hasField(fields []string, value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if containsField(fields, key) || hasField(fields, child) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if hasField(fields, child) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func redactValue(fields []string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if containsField(fields, key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(fields, child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(fields, child)
+		}
+	}
+
+	return value
+}
+
+func removeFields(fields []string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if containsField(fields, key) {
+				delete(v, key)
+				continue
+			}
+			v[key] = removeFields(fields, child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = removeFields(fields, child)
+		}
+	}
+
+	return value
+}
+
+func withoutIDs(rules []mock.Rule) []mock.Rule {
+	result := make([]mock.Rule, 0, len(rules))
+	for _, rule := range rules {
+		rule.ID = ""
+		result = append(result, rule)
+	}
+	return result
+}
+
+func containsHeader(headers []string, name string) bool {
+	for _, header := range headers {
+		if http.CanonicalHeaderKey(header) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsField(fields []string, name string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package recorder_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/mock"
+	. "github.com/mockingio/mockingio/engine/recorder"
+)
+
+func TestRecord(t *testing.T) {
+	cfg := &mock.Record{
+		Enabled:           true,
+		RedactHeaders:     []string{"authorization", "Set-Cookie"},
+		RedactBodyFields:  []string{"token"},
+		MatchHeaders:      []string{"X-Tenant", "Authorization"},
+		MatchQueryStrings: true,
+		MatchBody:         true,
+	}
+
+	mok := &mock.Mock{}
+	exchange := newExchange(http.MethodPost, "/login?b=2&a=1", `{"user": "joe"}`, `{"user": {"token": "secret"}, "id": 1}`)
+
+	assert.True(t, Record(mok, cfg, exchange))
+	require.Len(t, mok.Routes, 1)
+
+	route := mok.Routes[0]
+	assert.NotEmpty(t, route.ID)
+	assert.Equal(t, http.MethodPost, route.Method)
+	assert.Equal(t, "/login", route.Path)
+	require.Len(t, route.Responses, 1)
+
+	response := route.Responses[0]
+	assert.Equal(t, http.StatusCreated, response.Status)
+	assert.JSONEq(t, `{"user": {"token": "REDACTED"}, "id": 1}`, response.Body)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json", "Set-Cookie": "REDACTED"}, response.Headers)
+	assert.Equal(t, mock.And, response.RuleAggregation)
+
+	var rules []string
+	for _, rule := range response.Rules {
+		assert.NotEmpty(t, rule.ID)
+		rules = append(rules, string(rule.Target)+":"+rule.Modifier+"="+rule.Value)
+	}
+	assert.Equal(t, []string{"header:X-Tenant=acme", "query_string:a=1", "query_string:b=2", `body:={"user":"joe"}`}, rules)
+
+	t.Run("same exchange is not recorded twice", func(t *testing.T) {
+		assert.False(t, Record(mok, cfg, exchange))
+		assert.Len(t, mok.Routes[0].Responses, 1)
+	})
+
+	t.Run("different rules are added as a new response of the route", func(t *testing.T) {
+		other := newExchange(http.MethodPost, "/login?a=3", `{"user": "jane"}`, `{}`)
+		assert.True(t, Record(mok, cfg, other))
+		assert.Len(t, mok.Routes, 1)
+		assert.Len(t, mok.Routes[0].Responses, 2)
+	})
+
+	t.Run("redacted request body is matched without the redacted fields", func(t *testing.T) {
+		other := newExchange(http.MethodPost, "/login?a=4", `{"user": "joe", "token": "secret"}`, `{}`)
+		assert.True(t, Record(mok, cfg, other))
+		require.Len(t, mok.Routes[0].Responses, 3)
+
+		rules := mok.Routes[0].Responses[2].Rules
+		body := rules[len(rules)-1]
+		assert.Equal(t, mock.Body, body.Target)
+		assert.Equal(t, mock.JSONContains, body.Operator)
+		assert.JSONEq(t, `{"user": "joe"}`, body.Value)
+	})
+
+	t.Run("request without query and body is matched", func(t *testing.T) {
+		other := newExchange(http.MethodPost, "/login", "", `{}`)
+		assert.True(t, Record(mok, cfg, other))
+		require.Len(t, mok.Routes[0].Responses, 4)
+
+		var rules []string
+		for _, rule := range mok.Routes[0].Responses[3].Rules {
+			require.NoError(t, rule.Validate())
+			rules = append(rules, string(rule.Target)+":"+string(rule.Operator))
+		}
+		assert.Equal(t, []string{"header:equal", "raw_query:absent", "body:absent"}, rules)
+	})
+
+	t.Run("different path is added as a new route", func(t *testing.T) {
+		other := newExchange(http.MethodGet, "/users", "", `[]`)
+		assert.True(t, Record(mok, nil, other))
+		require.Len(t, mok.Routes, 2)
+		assert.Empty(t, mok.Routes[1].Responses[0].Rules)
+		assert.Equal(t, "[]", mok.Routes[1].Responses[0].Body)
+	})
+}
+
+func newExchange(method, url, requestBody, responseBody string) Exchange {
+	req := httptest.NewRequest(method, url, strings.NewReader(requestBody))
+	req.Header.Set("X-Tenant", "acme")
+	req.Header.Set("Authorization", "Bearer 123")
+
+	return Exchange{
+		Request:     req,
+		RequestBody: []byte(requestBody),
+		Response: &http.Response{
+			StatusCode: http.StatusCreated,
+			Header: http.Header{
+				"Content-Type":   {"application/json"},
+				"Content-Length": {"10"},
+				"Set-Cookie":     {"session=123"},
+			},
+		},
+		ResponseBody: []byte(responseBody),
+	}
+}
@@ -0,0 +1,308 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+const defaultPerPage = 10
+
+type item = map[string]any
+
+// Handler serves the in-memory REST collections of a mock.
+// The items are stored in the engine DB, keyed by the active session.
+type Handler struct {
+	mu sync.Mutex
+	db database.EngineDB
+}
+
+func New(db database.EngineDB) *Handler {
+	return &Handler{db: db}
+}
+
+// Serve serves the request if it targets one of the mock resources, it returns false otherwise
+func (h *Handler) Serve(w http.ResponseWriter, r *http.Request, mok *mock.Mock, sessionID string) bool {
+	for _, res := range mok.Resources {
+		id, ok := matchPath(res.Path, r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		c := &collection{
+			db:        h.db,
+			mock:      mok,
+			resource:  res,
+			sessionID: sessionID,
+		}
+
+		if id == "" {
+			c.serveCollection(w, r)
+		} else {
+			c.serveItem(w, r, id)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// matchPath returns the item ID if the path targets an item, or an empty ID if it targets the collection
+func matchPath(resourcePath, requestPath string) (string, bool) {
+	resourcePath = strings.TrimRight(resourcePath, "/")
+	requestPath = strings.TrimRight(requestPath, "/")
+
+	if requestPath == resourcePath {
+		return "", true
+	}
+
+	id := strings.TrimPrefix(requestPath, resourcePath+"/")
+	if id == requestPath || id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+
+	return id, true
+}
+
+type collection struct {
+	db        database.EngineDB
+	mock      *mock.Mock
+	resource  *mock.Resource
+	sessionID string
+}
+
+func (c *collection) serveCollection(w http.ResponseWriter, r *http.Request) {
+	items, err := c.load(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		page, err := paginate(items, r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+		writeJSON(w, http.StatusOK, page)
+	case http.MethodPost:
+		newItem, err := readItem(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		idField := c.resource.ID()
+		if _, ok := newItem[idField]; !ok {
+			newItem[idField] = uuid.NewString()
+		}
+		if _, idx := find(items, idField, toID(newItem[idField])); idx >= 0 {
+			writeError(w, http.StatusConflict, errors.New("item already exists"))
+			return
+		}
+
+		if err := c.save(r.Context(), append(items, newItem)); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, newItem)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+func (c *collection) serveItem(w http.ResponseWriter, r *http.Request, id string) {
+	items, err := c.load(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	idField := c.resource.ID()
+	existing, idx := find(items, idField, id)
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, errors.New("item not found"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPut, http.MethodPatch:
+		updated, err := readItem(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if r.Method == http.MethodPatch {
+			for k, v := range updated {
+				existing[k] = v
+			}
+			updated = existing
+		}
+		updated[idField] = existing[idField]
+
+		items[idx] = updated
+		if err := c.save(r.Context(), items); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		items = append(items[:idx], items[idx+1:]...)
+		if err := c.save(r.Context(), items); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+func (c *collection) key() string {
+	return fmt.Sprintf("%s/resources%s", c.sessionID, c.resource.Path)
+}
+
+// load returns the items of the active session, the collection is seeded on first access
+func (c *collection) load(ctx context.Context) ([]item, error) {
+	value, err := c.db.Get(ctx, c.mock.ID, c.key())
+	if err != nil {
+		return nil, errors.Wrap(err, "get resource items")
+	}
+
+	if value == "" {
+		return c.seed()
+	}
+
+	var items []item
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return nil, errors.Wrap(err, "unmarshal resource items")
+	}
+
+	return items, nil
+}
+
+func (c *collection) seed() ([]item, error) {
+	items := []item{}
+	if c.resource.SeedFile == "" {
+		return items, nil
+	}
+
+	data, err := os.ReadFile(c.mock.ResolvePath(c.resource.SeedFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "read seed file")
+	}
+
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, errors.Wrap(err, "unmarshal seed file")
+	}
+
+	return items, nil
+}
+
+func (c *collection) save(ctx context.Context, items []item) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return errors.Wrap(err, "marshal resource items")
+	}
+
+	return c.db.Set(ctx, c.mock.ID, c.key(), string(data))
+}
+
+func paginate(items []item, r *http.Request) ([]item, error) {
+	query := r.URL.Query()
+	if query.Get("page") == "" && query.Get("per_page") == "" {
+		return items, nil
+	}
+
+	page, err := queryInt(query.Get("page"), 1)
+	if err != nil || page < 1 {
+		return nil, errors.New("invalid page")
+	}
+
+	perPage, err := queryInt(query.Get("per_page"), defaultPerPage)
+	if err != nil || perPage < 1 {
+		return nil, errors.New("invalid per_page")
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []item{}, nil
+	}
+
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+func queryInt(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func find(items []item, idField, id string) (item, int) {
+	for i, it := range items {
+		if toID(it[idField]) == id {
+			return it, i
+		}
+	}
+	return nil, -1
+}
+
+// toID converts an ID to string, JSON numbers are decoded as float64
+func toID(value any) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func readItem(r *http.Request) (item, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read request body")
+	}
+
+	var it item
+	if err := json.Unmarshal(data, &it); err != nil || it == nil {
+		return nil, errors.New("request body must be a JSON object")
+	}
+
+	return it, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	body, _ := json.Marshal(data)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
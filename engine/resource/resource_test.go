@@ -0,0 +1,151 @@
+package resource_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/mock"
+	. "github.com/mockingio/mockingio/engine/resource"
+)
+
+func TestHandler_Serve(t *testing.T) {
+	mok := &mock.Mock{
+		ID:        "mock-id",
+		FilePath:  "fixtures/mock.yml",
+		Resources: []*mock.Resource{{Path: "/products", SeedFile: "products.json"}},
+	}
+	handler := New(memory.New())
+
+	serve := func(method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		served := handler.Serve(w, httptest.NewRequest(method, url, strings.NewReader(body)), mok, "session-1")
+		require.True(t, served)
+		return w
+	}
+
+	t.Run("list seeded items", func(t *testing.T) {
+		w := serve(http.MethodGet, "/products", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Len(t, decodeList(t, w), 3)
+	})
+
+	t.Run("list with pagination", func(t *testing.T) {
+		w := serve(http.MethodGet, "/products?page=2&per_page=2", "")
+		items := decodeList(t, w)
+		require.Len(t, items, 1)
+		assert.Equal(t, "Product 3", items[0]["name"])
+
+		w = serve(http.MethodGet, "/products?page=3&per_page=2", "")
+		assert.Empty(t, decodeList(t, w))
+
+		w = serve(http.MethodGet, "/products?page=0", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get item", func(t *testing.T) {
+		w := serve(http.MethodGet, "/products/2", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": 2, "name": "Product 2"}`, w.Body.String())
+
+		w = serve(http.MethodGet, "/products/20", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("create item", func(t *testing.T) {
+		w := serve(http.MethodPost, "/products", `{"name": "Product 4"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var created map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.NotEmpty(t, created["id"])
+
+		w = serve(http.MethodGet, "/products/"+created["id"].(string), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serve(http.MethodPost, "/products", `{"id": 1}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = serve(http.MethodPost, "/products", `[]`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("replace item", func(t *testing.T) {
+		w := serve(http.MethodPut, "/products/1", `{"id": 100, "title": "New"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": 1, "title": "New"}`, w.Body.String())
+	})
+
+	t.Run("patch item", func(t *testing.T) {
+		w := serve(http.MethodPatch, "/products/2", `{"price": 10}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id": 2, "name": "Product 2", "price": 10}`, w.Body.String())
+	})
+
+	t.Run("delete item", func(t *testing.T) {
+		w := serve(http.MethodDelete, "/products/3", "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = serve(http.MethodGet, "/products/3", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("new session starts from the seed data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Serve(w, httptest.NewRequest(http.MethodGet, "/products", nil), mok, "session-2")
+		assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+		assert.JSONEq(t, `{"id": 1, "name": "Product 1"}`, mustJSON(t, decodeList(t, w)[0]))
+	})
+
+	t.Run("not a resource path", func(t *testing.T) {
+		for _, url := range []string{"/users", "/products/1/reviews", "/productsx"} {
+			served := handler.Serve(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil), mok, "session-1")
+			assert.False(t, served, url)
+		}
+	})
+}
+
+func TestHandler_Serve_SeedFileError(t *testing.T) {
+	mok := &mock.Mock{
+		ID:        "mock-id",
+		Resources: []*mock.Resource{{Path: "/products", SeedFile: "not-found.json"}},
+	}
+
+	w := httptest.NewRecorder()
+	New(memory.New()).Serve(w, httptest.NewRequest(http.MethodGet, "/products", nil), mok, "session-1")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func decodeList(t *testing.T, w *httptest.ResponseRecorder) []map[string]any {
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	return items
+}
+
+func mustJSON(t *testing.T, value any) string {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestHandler_Serve_CustomIDField(t *testing.T) {
+	db := memory.New()
+	mok := &mock.Mock{ID: "mock-id", Resources: []*mock.Resource{{Path: "/users", IDField: "user_id"}}}
+
+	w := httptest.NewRecorder()
+	New(db).Serve(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"user_id": "joe"}`)), mok, "session")
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	value, err := db.Get(context.Background(), "mock-id", "session/resources/users")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"user_id": "joe"}]`, value)
+}
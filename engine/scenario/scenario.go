@@ -0,0 +1,87 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// Started is the state of a scenario before any transition
+const Started = "started"
+
+// Scenario is a named state machine shared across the routes of a mock
+type Scenario struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// GetState returns the current state of the scenario in the session
+func GetState(ctx context.Context, db database.StateReadWriter, mockID, sessionID, name string) (string, error) {
+	state, err := db.Get(ctx, mockID, key(sessionID, name))
+	if err != nil {
+		return "", errors.Wrap(err, "get scenario state")
+	}
+
+	if state == "" {
+		return Started, nil
+	}
+
+	return state, nil
+}
+
+// SetState moves the scenario in the session to the given state
+func SetState(ctx context.Context, db database.StateReadWriter, mockID, sessionID, name, state string) error {
+	if err := db.Set(ctx, mockID, key(sessionID, name), state); err != nil {
+		return errors.Wrap(err, "set scenario state")
+	}
+
+	return nil
+}
+
+// Names returns the scenarios used by the responses of the mock
+func Names(mok *mock.Mock) []string {
+	unique := map[string]bool{}
+	for _, route := range mok.Routes {
+		for _, response := range route.Responses {
+			if response.Scenario != "" {
+				unique[response.Scenario] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// List returns the scenarios of the mock with their state in the active session
+func List(ctx context.Context, db database.StateReadWriter, mok *mock.Mock) ([]Scenario, error) {
+	sessionID, err := db.GetActiveSession(ctx, mok.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get active session")
+	}
+
+	scenarios := []Scenario{}
+	for _, name := range Names(mok) {
+		state, err := GetState(ctx, db, mok.ID, sessionID, name)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, Scenario{Name: name, State: state})
+	}
+
+	return scenarios, nil
+}
+
+func key(sessionID, name string) string {
+	return fmt.Sprintf("%s/scenarios/%s", sessionID, name)
+}
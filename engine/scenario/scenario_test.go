@@ -0,0 +1,52 @@
+package scenario_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/mock"
+	. "github.com/mockingio/mockingio/engine/scenario"
+)
+
+func TestState(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	state, err := GetState(ctx, db, "mock-id", "session-1", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, Started, state)
+
+	require.NoError(t, SetState(ctx, db, "mock-id", "session-1", "checkout", "paid"))
+
+	state, err = GetState(ctx, db, "mock-id", "session-1", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "paid", state)
+
+	state, err = GetState(ctx, db, "mock-id", "session-2", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, Started, state)
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	mok := &mock.Mock{
+		ID: "mock-id",
+		Routes: []*mock.Route{
+			{Responses: []mock.Response{{Scenario: "login"}, {Scenario: "checkout"}, {}}},
+			{Responses: []mock.Response{{Scenario: "checkout"}}},
+		},
+	}
+	_ = db.SetActiveSession(ctx, "mock-id", "session-1")
+	_ = SetState(ctx, db, "mock-id", "session-1", "login", "done")
+
+	assert.Equal(t, []string{"checkout", "login"}, Names(mok))
+
+	scenarios, err := List(ctx, db, mok)
+	require.NoError(t, err)
+	assert.Equal(t, []Scenario{{Name: "checkout", State: Started}, {Name: "login", State: "done"}}, scenarios)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mockingio/mockingio/engine"
+	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// router dispatches the requests of a port to its mocks, by the Host header and the path prefix of the mocks.
+// Mocks are read from the database on each request, so changes of their hosts or path prefix apply right away.
+type router struct {
+	db database.EngineDB
+
+	mu      sync.RWMutex
+	engines map[string]*engine.Engine
+}
+
+func newRouter(db database.EngineDB) *router {
+	return &router{db: db, engines: map[string]*engine.Engine{}}
+}
+
+// add serves the mock on the port, mocks must differ by their hosts or path prefix.
+// A running mock must be stopped before it is served again.
+func (r *router) add(ctx context.Context, mo *mock.Mock, eng *engine.Engine) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.engines[mo.ID]; ok {
+		return fmt.Errorf("mock %v is already served on port %v", mo.ID, mo.Port)
+	}
+
+	for _, mockID := range r.mockIDs() {
+		other, err := r.db.GetMock(ctx, mockID)
+		if err != nil || other == nil || other.ID == mo.ID {
+			continue
+		}
+		if overlap(mo, other) {
+			return fmt.Errorf("mock %v already serves the same hosts and path prefix on port %v, set hosts or path_prefix", other.ID, mo.Port)
+		}
+	}
+
+	r.engines[mo.ID] = eng
+
+	return nil
+}
+
+// remove stops serving the mock, and reports whether it was the last mock of the port
+func (r *router) remove(mockID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.engines[mockID]; !ok {
+		return false
+	}
+	delete(r.engines, mockID)
+
+	return len(r.engines) == 0
+}
+
+func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	mo, eng := r.pick(req)
+	if eng == nil {
+		http.Error(w, "No mock matched", http.StatusNotFound)
+		return
+	}
+
+	if mo.PathPrefix != "" {
+		path, _ := mo.TrimPathPrefix(req.URL.Path)
+		req = req.Clone(req.Context())
+		req.URL.Path = path
+		req.URL.RawPath = ""
+	}
+
+	eng.Handler(w, req)
+}
+
+// pick returns the mock of the request. Mocks with hosts are preferred over mocks for all hosts,
+// then the longest path prefix wins.
+func (r *router) pick(req *http.Request) (*mock.Mock, *engine.Engine) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var picked *mock.Mock
+	var pickedEngine *engine.Engine
+	for _, mockID := range r.mockIDs() {
+		mo, err := r.db.GetMock(req.Context(), mockID)
+		if err != nil || mo == nil {
+			log.WithError(err).WithField("mock_id", mockID).Error("get mock to route request")
+			continue
+		}
+
+		if !mo.MatchHost(req.Host) {
+			continue
+		}
+		if _, ok := mo.TrimPathPrefix(req.URL.Path); !ok {
+			continue
+		}
+
+		if picked == nil || moreSpecific(mo, picked) {
+			picked, pickedEngine = mo, r.engines[mockID]
+		}
+	}
+
+	return picked, pickedEngine
+}
+
+// mockIDs returns the sorted mock IDs, so routing doesn't depend on the map order
+func (r *router) mockIDs() []string {
+	ids := make([]string, 0, len(r.engines))
+	for id := range r.engines {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func moreSpecific(a, b *mock.Mock) bool {
+	if (len(a.Hosts) > 0) != (len(b.Hosts) > 0) {
+		return len(a.Hosts) > 0
+	}
+
+	return len(strings.TrimRight(a.PathPrefix, "/")) > len(strings.TrimRight(b.PathPrefix, "/"))
+}
+
+// overlap reports whether both mocks would receive the same requests
+func overlap(a, b *mock.Mock) bool {
+	if strings.TrimRight(a.PathPrefix, "/") != strings.TrimRight(b.PathPrefix, "/") {
+		return false
+	}
+
+	if len(a.Hosts) == 0 || len(b.Hosts) == 0 {
+		return len(a.Hosts) == len(b.Hosts)
+	}
+
+	for _, host := range a.Hosts {
+		for _, other := range b.Hosts {
+			if strings.EqualFold(host, other) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
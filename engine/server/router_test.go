@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine"
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/journal"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+func TestRouter(t *testing.T) {
+	db := memory.New()
+	r := newRouter(db)
+
+	add := func(id string, hosts []string, pathPrefix string) {
+		mok := &mock.Mock{
+			ID:         id,
+			Hosts:      hosts,
+			PathPrefix: pathPrefix,
+			Routes: []*mock.Route{{
+				ID:        id + "-route",
+				Method:    http.MethodGet,
+				Path:      "/hello",
+				Responses: []mock.Response{{ID: id + "-response", Status: 200, Body: id}},
+			}},
+		}
+		require.NoError(t, db.SetMock(context.Background(), mok))
+		require.NoError(t, r.add(context.Background(), mok, engine.New(id, db)))
+	}
+
+	add("default", nil, "")
+	add("wildcard", []string{"*.example.com"}, "")
+	add("api", []string{"api.example.com"}, "/v1")
+	add("admin", nil, "/admin/")
+
+	tests := []struct {
+		name   string
+		host   string
+		path   string
+		status int
+		body   string
+	}{
+		{"no host, no prefix", "localhost:8080", "/hello", 200, "default"},
+		{"path prefix", "localhost", "/admin/hello", 200, "admin"},
+		{"path prefix of a partial segment", "localhost", "/administrator/hello", 404, ""},
+		{"wildcard host", "www.example.com:8080", "/hello", 200, "wildcard"},
+		{"host and path prefix", "API.example.com", "/v1/hello", 200, "api"},
+		{"hosts beat path prefix", "api.example.com", "/admin/hello", 404, ""},
+		{"host without the path prefix", "api.example.com", "/hello", 200, "wildcard"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Host = tt.host
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("mocks with the same hosts and path prefix", func(t *testing.T) {
+		err := r.add(context.Background(), &mock.Mock{ID: "other", PathPrefix: "/admin"}, nil)
+		assert.Error(t, err)
+
+		err = r.add(context.Background(), &mock.Mock{ID: "other", Hosts: []string{"API.example.com"}, PathPrefix: "/v1"}, nil)
+		assert.Error(t, err)
+
+		err = r.add(context.Background(), &mock.Mock{ID: "other", Hosts: []string{"api.example.com"}}, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.False(t, r.remove("missing"))
+		for _, id := range []string{"default", "wildcard", "api", "admin"} {
+			assert.False(t, r.remove(id))
+		}
+		assert.True(t, r.remove("other"))
+	})
+}
+
+func TestRouter_PathRule(t *testing.T) {
+	db := memory.New()
+	r := newRouter(db)
+
+	mok := &mock.Mock{
+		ID:         "users",
+		PathPrefix: "/users",
+		Routes: []*mock.Route{{
+			Method: http.MethodGet,
+			Path:   "/:id",
+			Responses: []mock.Response{
+				{
+					Status: 200,
+					Body:   "full path",
+					Rules:  []mock.Rule{{Target: mock.Path, Operator: mock.Equal, Value: "/users/42"}},
+				},
+				{
+					Status: 200,
+					Body:   "path without the prefix",
+					Rules:  []mock.Rule{{Target: mock.Path, Operator: mock.Equal, Value: "/42"}},
+				},
+			},
+		}},
+	}
+	require.NoError(t, db.SetMock(context.Background(), mok))
+	require.NoError(t, r.add(context.Background(), mok, engine.New(mok.ID, db)))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "full path", w.Body.String())
+
+	// the journal keeps the full URL, so the rule passes in verifications too
+	entries, err := db.GetRequests(context.Background(), mok.ID, journal.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/users/42", entries[0].URL)
+	assert.Equal(t, "/42", entries[0].Path)
+}
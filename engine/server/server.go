@@ -3,25 +3,33 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	_ "embed"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/mockingio/mockingio/engine"
 	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/grpcmock"
 	"github.com/mockingio/mockingio/engine/mock"
 )
 
 const (
 	serverURLFormat    = "http://127.0.0.1:%v"
 	serverURLTLSFormat = "https://127.0.0.1:%v"
+	grpcAddressFormat  = "127.0.0.1:%v"
 )
 
 var (
@@ -36,10 +44,24 @@ type Server struct {
 	mu               sync.Mutex
 	db               database.EngineDB
 	mockServerStates map[string]*MockServerState
+	// portListeners are the listeners of the mocks with a port, by port
+	portListeners map[string]*portListener
+}
+
+// portListener is the HTTP server of a port, shared by the mocks of the port
+type portListener struct {
+	router *router
+	url    string
+	tls    *mock.TLS
+	close  func()
 }
 
 func New(db database.EngineDB) *Server {
-	return &Server{db: db, mockServerStates: make(map[string]*MockServerState)}
+	return &Server{
+		db:               db,
+		mockServerStates: make(map[string]*MockServerState),
+		portListeners:    make(map[string]*portListener),
+	}
 }
 
 func (s *Server) NewMockServerByID(ctx context.Context, id string) (*MockServerState, error) {
@@ -57,24 +79,97 @@ func (s *Server) NewMockServerByID(ctx context.Context, id string) (*MockServerS
 
 func (s *Server) NewMockServer(ctx context.Context, mo *mock.Mock) (*MockServerState, error) {
 	eng := engine.New(mo.ID, s.db)
-	srv := buildHTTPServer(eng)
 
+	pl, err := s.addToPortListener(ctx, mo, eng)
+	if err != nil {
+		return nil, err
+	}
+
+	var grpcServer *grpc.Server
+	var grpcAddress string
+	if mo.GRPC != nil {
+		grpcServer, grpcAddress, err = s.startGRPCServer(ctx, mo)
+		if err != nil {
+			s.removeFromPortListener(pl, mo.ID)
+			return nil, errors.Wrap(err, "start gRPC server")
+		}
+	}
+
+	serverURL := pl.url + strings.TrimRight(mo.PathPrefix, "/")
+	state := s.addNewMockServerState(mo.ID, serverURL, func() {
+		fmt.Printf("shutting down server: %v\n", serverURL)
+		s.removeFromPortListener(pl, mo.ID)
+		eng.Close()
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+	})
+	state.GRPCAddress = grpcAddress
+
+	return state, nil
+}
+
+// addToPortListener serves the mock on its port. Mocks with the same port share the listener,
+// they must have the same TLS config.
+func (s *Server) addToPortListener(ctx context.Context, mo *mock.Mock, eng *engine.Engine) (*portListener, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pl, ok := s.portListeners[mo.Port]; ok && mo.Port != "" {
+		if !reflect.DeepEqual(pl.tls, tlsConfig(mo)) {
+			return nil, fmt.Errorf("port %v is already served with another TLS config", mo.Port)
+		}
+		if err := pl.router.add(ctx, mo, eng); err != nil {
+			return nil, err
+		}
+		return pl, nil
+	}
+
+	pl, err := s.listen(ctx, mo)
+	if err != nil {
+		return nil, err
+	}
+	if err := pl.router.add(ctx, mo, eng); err != nil {
+		pl.close()
+		return nil, err
+	}
+	if mo.Port != "" {
+		s.portListeners[mo.Port] = pl
+	}
+
+	return pl, nil
+}
+
+// removeFromPortListener stops serving the mock, the listener is closed when no mock is left on the port
+func (s *Server) removeFromPortListener(pl *portListener, mockID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !pl.router.remove(mockID) {
+		return
+	}
+
+	pl.close()
+	for port, listener := range s.portListeners {
+		if listener == pl {
+			delete(s.portListeners, port)
+		}
+	}
+}
+
+// listen starts the HTTP server of a port
+func (s *Server) listen(ctx context.Context, mo *mock.Mock) (*portListener, error) {
 	var listener net.Listener
 	var err error
 
 	lAddr := "0.0.0.0:" + mo.Port
 	if mo.TLSEnabled() {
-		cert, err := getTLSCert(mo)
+		config, err := getTLSConfig(mo)
 		if err != nil {
-			return nil, errors.Wrap(err, "get TLS cert")
-		}
-		if cert == nil {
-			return nil, errors.New("no TLS cert found")
+			return nil, err
 		}
 
-		listener, err = tls.Listen("tcp", lAddr, &tls.Config{
-			Certificates: []tls.Certificate{*cert},
-		})
+		listener, err = tls.Listen("tcp", lAddr, config)
 		if err != nil {
 			return nil, errors.Wrap(err, "listen TLS TCP")
 		}
@@ -85,11 +180,14 @@ func (s *Server) NewMockServer(ctx context.Context, mo *mock.Mock) (*MockServerS
 		}
 	}
 
+	r := newRouter(s.db)
+	srv := buildHTTPServer(r)
 	shutdownC := make(chan bool, 1)
 
 	serverPort := listener.Addr().(*net.TCPAddr).Port
 	go func() {
-		if err := srv.Serve(listener); err != nil {
+		err := srv.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
 			log.Error(errors.Wrapf(err, "serving HTTP at %v", listener.Addr().String()))
 		}
 	}()
@@ -103,14 +201,48 @@ func (s *Server) NewMockServer(ctx context.Context, mo *mock.Mock) (*MockServerS
 	if mo.TLSEnabled() {
 		urlFormat = serverURLTLSFormat
 	}
-	serverURL := fmt.Sprintf(urlFormat, serverPort)
 
-	state := s.addNewMockServerState(mo.ID, serverURL, func() {
-		fmt.Printf("shutting down server: %v\n", serverURL)
-		shutdownC <- true
-	})
+	return &portListener{
+		router: r,
+		url:    fmt.Sprintf(urlFormat, serverPort),
+		tls:    tlsConfig(mo),
+		close: func() {
+			// the port is released right away, the open connections are closed gracefully
+			_ = listener.Close()
+			shutdownC <- true
+		},
+	}, nil
+}
 
-	return state, nil
+// startGRPCServer serves the gRPC methods of the mock on its own listener, and returns the listener address
+func (s *Server) startGRPCServer(ctx context.Context, mo *mock.Mock) (*grpc.Server, string, error) {
+	methods, err := grpcmock.LoadMethods(ctx, mo)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var opts []grpc.ServerOption
+	if mo.TLSEnabled() {
+		config, err := getTLSConfig(mo)
+		if err != nil {
+			return nil, "", err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+	}
+
+	listener, err := net.Listen("tcp", "0.0.0.0:"+mo.GRPC.Port)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "listen gRPC TCP")
+	}
+
+	srv := grpcmock.NewServer(grpcmock.New(mo.ID, s.db, methods), opts...)
+	go func() {
+		if err := srv.Serve(listener); err != nil {
+			log.Error(errors.Wrapf(err, "serving gRPC at %v", listener.Addr().String()))
+		}
+	}()
+
+	return srv, fmt.Sprintf(grpcAddressFormat, listener.Addr().(*net.TCPAddr).Port), nil
 }
 
 func (s *Server) GetMockServerURLs() []string {
@@ -124,6 +256,18 @@ func (s *Server) GetMockServerURLs() []string {
 	return urls
 }
 
+// GetGRPCAddresses returns the addresses of the running gRPC listeners
+func (s *Server) GetGRPCAddresses() []string {
+	var addresses []string
+	for _, state := range s.mockServerStates {
+		if state.Status == Running && state.GRPCAddress != "" {
+			addresses = append(addresses, state.GRPCAddress)
+		}
+	}
+
+	return addresses
+}
+
 func (s *Server) StopMockServer(mockID string) (*MockServerState, error) {
 	state, err := s.getMockServerState(mockID)
 	if err != nil {
@@ -135,6 +279,15 @@ func (s *Server) StopMockServer(mockID string) (*MockServerState, error) {
 	return state, nil
 }
 
+// RestartMockServer stops the server of the mock, if it is running, and starts it with the new mock
+func (s *Server) RestartMockServer(ctx context.Context, mo *mock.Mock) (*MockServerState, error) {
+	if state, err := s.getMockServerState(mo.ID); err == nil && state.Status == Running {
+		state.shutdownServer()
+	}
+
+	return s.NewMockServer(ctx, mo)
+}
+
 func (s *Server) GetMockServerStates() map[string]*MockServerState {
 	return s.mockServerStates
 }
@@ -168,13 +321,64 @@ func (s *Server) getMockServerState(mockID string) (*MockServerState, error) {
 	return nil, fmt.Errorf("mock server: %v not found", mockID)
 }
 
-func buildHTTPServer(e *engine.Engine) *http.Server {
+func buildHTTPServer(handler http.Handler) *http.Server {
 	r := mux.NewRouter()
-	r.PathPrefix("/").HandlerFunc(e.Handler)
+	r.PathPrefix("/").Handler(handler)
 
 	return &http.Server{Handler: r}
 }
 
+// tlsConfig returns the TLS config of the mock, nil if TLS is disabled
+func tlsConfig(mo *mock.Mock) *mock.TLS {
+	if !mo.TLSEnabled() {
+		return nil
+	}
+
+	return mo.TLS
+}
+
+// getTLSConfig returns the TLS config of the mock server, with the client certificate mode for mutual TLS
+func getTLSConfig(mo *mock.Mock) (*tls.Config, error) {
+	cert, err := getTLSCert(mo)
+	if err != nil {
+		return nil, errors.Wrap(err, "get TLS cert")
+	}
+	if cert == nil {
+		return nil, errors.New("no TLS cert found")
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+	}
+
+	if mo.TLS.ClientCAPath != "" {
+		caPath := mo.ResolvePath(mo.TLS.ClientCAPath)
+		pem, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read client CA file: %v", caPath)
+		}
+
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in client CA file: %v", caPath)
+		}
+	}
+
+	switch mo.TLS.ClientAuth {
+	case mock.ClientAuthRequest:
+		config.ClientAuth = tls.RequestClientCert
+		if config.ClientCAs != nil {
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	case mock.ClientAuthRequire:
+		config.ClientAuth = tls.RequireAnyClientCert
+	case mock.ClientAuthVerify:
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
 func getTLSCert(mo *mock.Mock) (*tls.Certificate, error) {
 	if !mo.TLSEnabled() {
 		return nil, nil
@@ -2,12 +2,24 @@ package server
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	_ "embed"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -61,6 +73,24 @@ func TestServer_StopMockServer(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestServer_RestartMockServer(t *testing.T) {
+	db := setupDatabase()
+	server := New(db)
+	state, err := server.NewMockServerByID(context.Background(), "*mock-id-1*")
+	require.NoError(t, err)
+	defer server.StopAllServers()
+
+	// the same port is reused
+	url := state.URL
+	mok, _ := db.GetMock(context.Background(), "*mock-id-1*")
+	mok.Port = url[strings.LastIndex(url, ":")+1:]
+	newState, err := server.RestartMockServer(context.Background(), mok)
+	require.NoError(t, err)
+	assert.Equal(t, "running", newState.Status)
+	assert.Equal(t, url, newState.URL)
+	assert.Equal(t, "stopped", state.Status)
+}
+
 func TestServer_StopAllServers(t *testing.T) {
 	server := New(setupDatabase())
 	_, _ = server.NewMockServerByID(context.Background(), "*mock-id-1*")
@@ -179,3 +209,259 @@ func setupDatabase() database.EngineDB {
 
 	return db
 }
+
+func TestServer_GRPC(t *testing.T) {
+	db := memory.New()
+	mok := &mock.Mock{
+		ID:       "grpc-mock-id",
+		FilePath: "../grpcmock/fixtures/mock.yml",
+		GRPC: &mock.GRPC{
+			ProtoFiles: []string{"greeter.proto"},
+			Methods: []*mock.GRPCMethod{{
+				Name:      "helloworld.Greeter/SayHello",
+				Responses: []mock.GRPCResponse{{Body: `{"message": "hello"}`}},
+			}},
+		},
+	}
+	require.NoError(t, db.SetMock(context.Background(), mok))
+
+	server := New(db)
+	state, err := server.NewMockServer(context.Background(), mok)
+	require.NoError(t, err)
+	assert.NotEmpty(t, state.GRPCAddress)
+	assert.Equal(t, []string{state.GRPCAddress}, server.GetGRPCAddresses())
+
+	server.StopAllServers()
+	assert.Empty(t, state.GRPCAddress)
+	assert.Empty(t, server.GetGRPCAddresses())
+
+	t.Run("invalid proto files", func(t *testing.T) {
+		mok.GRPC.ProtoFiles = []string{"missing.proto"}
+		_, err := New(db).NewMockServer(context.Background(), mok)
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_SharedPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	_ = listener.Close()
+
+	db := memory.New()
+	newMock := func(yaml string) *mock.Mock {
+		mok, err := mock.FromYaml("port: \""+port+"\"\n"+yaml, mock.WithIDGeneration())
+		require.NoError(t, err)
+		require.NoError(t, db.SetMock(context.Background(), mok))
+		return mok
+	}
+
+	api := newMock(`
+hosts: [api.example.com]
+routes:
+  - method: GET
+    path: /hello
+    responses:
+      - status: 200
+        body: api
+`)
+	admin := newMock(`
+path_prefix: /admin
+routes:
+  - method: GET
+    path: /hello
+    responses:
+      - status: 200
+        body: admin
+`)
+
+	server := New(db)
+	defer server.StopAllServers()
+
+	apiState, err := server.NewMockServer(context.Background(), api)
+	require.NoError(t, err)
+	adminState, err := server.NewMockServer(context.Background(), admin)
+	require.NoError(t, err)
+	serverURL := apiState.URL
+	assert.Equal(t, serverURL+"/admin", adminState.URL)
+
+	get := func(host, path string) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, serverURL+path, nil)
+		require.NoError(t, err)
+		req.Host = host
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+		return res.StatusCode, string(body)
+	}
+
+	status, body := get("api.example.com", "/hello")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "api", body)
+
+	status, body = get("other.example.com", "/admin/hello")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "admin", body)
+
+	status, _ = get("other.example.com", "/hello")
+	assert.Equal(t, 404, status)
+
+	t.Run("same hosts and path prefix", func(t *testing.T) {
+		_, err := server.NewMockServer(context.Background(), newMock("path_prefix: /admin/\nroutes: [{method: GET, path: /, responses: [{status: 200}]}]"))
+		assert.Error(t, err)
+	})
+
+	t.Run("mock already running", func(t *testing.T) {
+		_, err := server.NewMockServer(context.Background(), admin)
+		assert.Error(t, err)
+
+		status, body := get("other.example.com", "/admin/hello")
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "admin", body)
+	})
+
+	t.Run("another TLS config", func(t *testing.T) {
+		_, err := server.NewMockServer(context.Background(), newMock("path_prefix: /tls\ntls: {enabled: true}\nroutes: [{method: GET, path: /, responses: [{status: 200}]}]"))
+		assert.Error(t, err)
+	})
+
+	t.Run("the port is served until the last mock is stopped", func(t *testing.T) {
+		_, err := server.StopMockServer(api.ID)
+		require.NoError(t, err)
+
+		status, body := get("other.example.com", "/admin/hello")
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "admin", body)
+
+		_, err = server.StopMockServer(admin.ID)
+		require.NoError(t, err)
+
+		_, err = http.Get(serverURL + "/admin/hello")
+		assert.Error(t, err)
+	})
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+
+	clientCert := func(cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+	billing := clientCert("billing", caCert, caKey)
+	shipping := clientCert("shipping", caCert, caKey)
+	selfSigned := clientCert("billing", nil, nil)
+
+	newMock := func(clientAuth mock.ClientAuth) *mock.Mock {
+		return &mock.Mock{
+			ID:  "*mock-id-1*",
+			TLS: &mock.TLS{Enabled: true, ClientCAPath: caPath, ClientAuth: clientAuth},
+			Routes: []*mock.Route{{
+				ID:     "route-id",
+				Method: http.MethodGet,
+				Path:   "/",
+				Responses: []mock.Response{
+					{
+						ID:              "billing",
+						Status:          200,
+						Body:            "billing",
+						RuleAggregation: mock.And,
+						Rules:           []mock.Rule{{Target: mock.ClientCertCN, Operator: mock.Equal, Value: "billing"}},
+					},
+					{ID: "default", Status: 200, Body: "other client"},
+				},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		clientAuth mock.ClientAuth
+		cert       *tls.Certificate
+		body       string
+		error      bool
+	}{
+		{"no client cert requested", "", &billing, "other client", false},
+		{"client cert requested, none given", mock.ClientAuthRequest, nil, "other client", false},
+		{"client cert requested and verified", mock.ClientAuthRequest, &billing, "billing", false},
+		{"client cert requested, not signed by the CA", mock.ClientAuthRequest, &selfSigned, "", true},
+		{"client cert required, none given", mock.ClientAuthRequire, nil, "", true},
+		{"client cert required, not verified", mock.ClientAuthRequire, &selfSigned, "billing", false},
+		{"client cert verified", mock.ClientAuthVerify, &billing, "billing", false},
+		{"another client cert verified", mock.ClientAuthVerify, &shipping, "other client", false},
+		{"client cert not signed by the CA", mock.ClientAuthVerify, &selfSigned, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mok := newMock(tt.clientAuth)
+			db := memory.New()
+			require.NoError(t, db.SetMock(context.Background(), mok))
+
+			server := New(db)
+			state, err := server.NewMockServer(context.Background(), mok)
+			require.NoError(t, err)
+			defer server.StopAllServers()
+
+			config := &tls.Config{InsecureSkipVerify: true}
+			if tt.cert != nil {
+				// the certificate is sent even if it is not signed by a CA of the server
+				config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					return tt.cert, nil
+				}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+
+			res, err := client.Get(state.URL)
+			if tt.error {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			body, _ := io.ReadAll(res.Body)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+
+	t.Run("invalid client CA file", func(t *testing.T) {
+		mok := newMock(mock.ClientAuthVerify)
+		mok.TLS.ClientCAPath = "./fixtures/certs/missing.pem"
+		_, err := New(memory.New()).NewMockServer(context.Background(), mok)
+		assert.Error(t, err)
+	})
+}
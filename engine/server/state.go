@@ -6,9 +6,11 @@ const (
 )
 
 type MockServerState struct {
-	MockID           string `json:"mock_id"`
-	URL              string `json:"url"`
-	Status           string `json:"status"`
+	MockID string `json:"mock_id"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	// GRPCAddress is the address of the gRPC listener, if the mock serves gRPC methods
+	GRPCAddress      string `json:"grpc_address,omitempty"`
 	shutdownServerFn func()
 }
 
@@ -18,4 +20,5 @@ func (s *MockServerState) shutdownServer() {
 	}
 	s.Status = Stopped
 	s.URL = ""
+	s.GRPCAddress = ""
 }
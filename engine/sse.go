@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// serveSSE streams the response events, each event is flushed as soon as it is written
+func (eng *Engine) serveSSE(
+	ctx context.Context,
+	w http.ResponseWriter,
+	req matcher.Context,
+	route *mock.Route,
+	response *mock.Response,
+) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/event-stream")
+	}
+	if w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	w.WriteHeader(response.Status)
+	flush(w)
+
+	// looping streams never end by themselves, they end with the request or the mock server
+	ctx, cancel := eng.untilClosed(ctx)
+	defer cancel()
+
+	sse := response.SSE
+	for i := 0; sse.Loop || i <= sse.Repeat; i++ {
+		for _, event := range sse.Events {
+			if !sleep(ctx, time.Duration(event.Delay)*time.Millisecond) {
+				return
+			}
+
+			// expanded for every event sent, so faker values change between repeats
+			event.Data = eng.expand(req, route, event.Data, response.Template)
+			event.ID = eng.expand(req, route, event.ID, response.Template)
+
+			if _, err := io.WriteString(w, event.String()); err != nil {
+				return
+			}
+			flush(w)
+		}
+	}
+}
+
+// expand runs the plugins on the value, as if it was a response body
+func (eng *Engine) expand(req matcher.Context, route *mock.Route, value string, template bool) string {
+	if value == "" {
+		return value
+	}
+
+	response := &mock.Response{Body: value, Headers: map[string]string{}, Template: template}
+	for _, plugin := range eng.plugins {
+		plugin.Response(req, route, response)
+	}
+
+	return response.Body
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
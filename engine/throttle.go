@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// waitFirstByte waits for the throttle time to first byte, or until the client goes away
+func waitFirstByte(ctx context.Context, throttle *mock.Throttle) {
+	if throttle == nil || throttle.TimeToFirstByte == 0 {
+		return
+	}
+
+	sleep(ctx, time.Duration(throttle.TimeToFirstByte)*time.Millisecond)
+}
+
+// writeBody copies the body to the client, in chunks flushed one by one if the response is throttled
+func writeBody(ctx context.Context, w http.ResponseWriter, body io.Reader, size int64, throttle *mock.Throttle) {
+	if throttle == nil {
+		_, _ = io.Copy(w, body)
+		return
+	}
+
+	chunkSize, wait := throttle.Chunks(size)
+	chunk := make([]byte, chunkSize)
+	for i := 0; ; i++ {
+		n, err := io.ReadFull(body, chunk)
+		if n == 0 {
+			return
+		}
+
+		if i > 0 && !sleep(ctx, wait) {
+			return
+		}
+
+		if _, err := w.Write(chunk[:n]); err != nil {
+			return
+		}
+		flush(w)
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// sleep waits for the duration, it returns false if the context is done before
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
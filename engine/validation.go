@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mockingio/mockingio/engine/openapi"
+)
+
+// validateRequest validates the request against the OpenAPI document of the mock, if any.
+// It returns the invalid fields, together with the status to respond with.
+func (eng *Engine) validateRequest(r *http.Request) ([]openapi.FieldError, int) {
+	if err := eng.reloadMock(r.Context()); err != nil {
+		return nil, 0
+	}
+
+	mok := eng.getMock()
+	if mok == nil || mok.Validation == nil {
+		return nil, 0
+	}
+
+	file := mok.ResolvePath(mok.Validation.OpenAPI)
+
+	eng.validatorMu.Lock()
+	// the document is loaded again when the mock is replaced, e.g. reloaded from its file
+	if eng.validator == nil || eng.validatorFile != file || eng.validatorMock != mok {
+		validator, err := openapi.NewValidator(file)
+		if err != nil {
+			eng.validatorMu.Unlock()
+			log.WithError(err).WithField("file", file).Error("load OpenAPI document")
+			return nil, 0
+		}
+		eng.validator, eng.validatorFile, eng.validatorMock = validator, file, mok
+	}
+	validator := eng.validator
+	eng.validatorMu.Unlock()
+
+	return validator.Validate(r), mok.Validation.StatusCode()
+}
+
+// invalidRequestHandler responds with the fields of the request not conforming to the OpenAPI document
+func (eng *Engine) invalidRequestHandler(w http.ResponseWriter, r *http.Request, fieldErrs []openapi.FieldError, status int) {
+	fields := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		fields = append(fields, fieldErr.Path())
+	}
+
+	log.WithFields(log.Fields{
+		"method": r.Method,
+		"path":   r.URL.Path,
+		"fields": fields,
+	}).Warn("request does not conform to the OpenAPI document")
+
+	body, err := json.Marshal(map[string][]openapi.FieldError{"errors": fieldErrs})
+	if err != nil {
+		log.WithError(err).Error("marshal validation errors")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
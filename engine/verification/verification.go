@@ -0,0 +1,327 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+
+	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/journal"
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// maxClosest is the maximum number of non-matching requests returned when a verification fails
+const maxClosest = 3
+
+type CountMode string
+
+const (
+	Exactly CountMode = "exactly"
+	AtLeast CountMode = "at_least"
+	AtMost  CountMode = "at_most"
+	Never   CountMode = "never"
+)
+
+// Verification describes the requests expected to be received by a mock
+type Verification struct {
+	Method    string      `yaml:"method" json:"method"`
+	Path      string      `yaml:"path" json:"path"`
+	Rules     []mock.Rule `yaml:"rules,omitempty" json:"rules,omitempty"`
+	Mode      CountMode   `yaml:"mode" json:"mode"`
+	Count     int         `yaml:"count,omitempty" json:"count,omitempty"`
+	SessionID string      `yaml:"session_id,omitempty" json:"session_id,omitempty"`
+}
+
+func (v Verification) Validate() error {
+	return validation.ValidateStruct(
+		&v,
+		validation.Field(&v.Path, validation.Required),
+		validation.Field(&v.Rules, validation.Each(validation.By(validateRule))),
+		validation.Field(&v.Mode, validation.Required, validation.In(Exactly, AtLeast, AtMost, Never)),
+		validation.Field(&v.Count, validation.Min(0)),
+	)
+}
+
+// unsupportedTargets are the targets the journal entries can't serve
+var unsupportedTargets = []mock.Target{
+	mock.RequestNumber,
+	mock.Message,
+	mock.ClientCertCN,
+	mock.ClientCertSAN,
+	mock.ClientCertFingerprint,
+}
+
+func validateRule(value interface{}) error {
+	rule, ok := value.(mock.Rule)
+	if !ok {
+		return nil
+	}
+
+	for _, target := range unsupportedTargets {
+		if rule.Target == target {
+			return fmt.Errorf("%s target is not supported", target)
+		}
+	}
+
+	return rule.Validate()
+}
+
+// Expected describes the expected number of calls, e.g. "at least 2 times"
+func (v Verification) Expected() string {
+	if v.Mode == Never {
+		return "never"
+	}
+
+	times := "times"
+	if v.Count == 1 {
+		times = "time"
+	}
+
+	return fmt.Sprintf("%s %d %s", strings.ReplaceAll(string(v.Mode), "_", " "), v.Count, times)
+}
+
+func (v Verification) satisfied(count int) bool {
+	switch v.Mode {
+	case Exactly:
+		return count == v.Count
+	case AtLeast:
+		return count >= v.Count
+	case AtMost:
+		return count <= v.Count
+	case Never:
+		return count == 0
+	default:
+		return false
+	}
+}
+
+// Result is the outcome of a verification
+type Result struct {
+	Passed   bool   `json:"passed"`
+	Count    int    `json:"count"`
+	Expected string `json:"expected"`
+	// Closest are the requests that came close to match, only set when the verification fails
+	Closest []Mismatch `json:"closest,omitempty"`
+}
+
+// Mismatch is a received request which does not match the verification
+type Mismatch struct {
+	Request *journal.Entry `json:"request"`
+	Diffs   []Diff         `json:"diffs"`
+}
+
+// Diff is a single check on a request, method, path or rule, with the expected and actual values
+type Diff struct {
+	Target   string `json:"target"`
+	Modifier string `json:"modifier,omitempty"`
+	Operator string `json:"operator"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Matched  bool   `json:"matched"`
+}
+
+func (m Mismatch) failures() int {
+	count := 0
+	for _, diff := range m.Diffs {
+		if !diff.Matched {
+			count++
+		}
+	}
+	return count
+}
+
+func (r Result) String() string {
+	if r.Passed {
+		return fmt.Sprintf("expected %s, received %d", r.Expected, r.Count)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("expected %s, received %d", r.Expected, r.Count))
+	for _, mismatch := range r.Closest {
+		sb.WriteString(fmt.Sprintf("\n  %s %s", mismatch.Request.Method, mismatch.Request.URL))
+		for _, diff := range mismatch.Diffs {
+			if diff.Matched {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n    %s", diff.Target))
+			if diff.Modifier != "" {
+				sb.WriteString(fmt.Sprintf("[%s]", diff.Modifier))
+			}
+			sb.WriteString(fmt.Sprintf(" %s %q, actual %q", diff.Operator, diff.Expected, diff.Actual))
+		}
+	}
+
+	return sb.String()
+}
+
+// DB is the database of the verifications, the mock resolves the files referenced by the rules, e.g. JSON schemas
+type DB interface {
+	database.RequestJournal
+	GetMock(ctx context.Context, id string) (*mock.Mock, error)
+}
+
+// Verify checks the received requests of the mock against the verification
+func Verify(ctx context.Context, db DB, mockID string, v Verification) (*Result, error) {
+	if err := v.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid verification")
+	}
+
+	entries, err := db.GetRequests(ctx, mockID, journal.Filter{SessionID: v.SessionID})
+	if err != nil {
+		return nil, errors.Wrap(err, "get requests")
+	}
+
+	mok, err := db.GetMock(ctx, mockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get mock")
+	}
+	if mok == nil {
+		// the journal of a removed mock can still be verified
+		mok = &mock.Mock{ID: mockID}
+	}
+	route := &mock.Route{Method: v.Method, Path: v.Path}
+
+	count := 0
+	var mismatches []Mismatch
+	for _, entry := range entries {
+		diffs, err := compare(ctx, mok, route, v.Rules, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		mismatch := Mismatch{Request: entry, Diffs: diffs}
+		if mismatch.failures() == 0 {
+			count++
+			continue
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+
+	result := &Result{
+		Passed:   v.satisfied(count),
+		Count:    count,
+		Expected: v.Expected(),
+	}
+
+	if !result.Passed {
+		sort.SliceStable(mismatches, func(i, j int) bool {
+			return mismatches[i].failures() < mismatches[j].failures()
+		})
+		if len(mismatches) > maxClosest {
+			mismatches = mismatches[:maxClosest]
+		}
+		result.Closest = mismatches
+	}
+
+	return result, nil
+}
+
+func compare(
+	ctx context.Context,
+	mok *mock.Mock,
+	route *mock.Route,
+	rules []mock.Rule,
+	entry *journal.Entry,
+) ([]Diff, error) {
+	var diffs []Diff
+
+	if route.Method != "" {
+		diffs = append(diffs, Diff{
+			Target:   "method",
+			Operator: string(mock.Equal),
+			Expected: route.Method,
+			Actual:   entry.Method,
+			Matched:  strings.EqualFold(route.Method, entry.Method),
+		})
+	}
+
+	diffs = append(diffs, Diff{
+		Target:   "path",
+		Operator: string(mock.Equal),
+		Expected: route.Path,
+		Actual:   entry.Path,
+		Matched:  matcher.MatchPath(route.Path, entry.Path),
+	})
+
+	for _, rule := range rules {
+		rule := rule
+		req, err := toHTTPRequest(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		// request_number is rejected by the validation, the rule matcher won't need the engine DB
+		ruleMatcher := matcher.NewRuleMatcher(mok, route, &rule, matcher.Context{
+			HTTPRequest: req,
+			SessionID:   entry.SessionID,
+		}, nil)
+
+		// a request that can't be matched, e.g. a body which is not JSON, counts as a mismatch
+		actual, err := ruleMatcher.GetTargetValue()
+		if err != nil {
+			actual = ""
+		}
+
+		matched, err := ruleMatcher.Match()
+		if err != nil {
+			matched = false
+		}
+
+		expected := rule.Value
+		if len(rule.Values) > 0 {
+			expected = strings.Join(rule.Values, ", ")
+		}
+
+		diffs = append(diffs, Diff{
+			Target:   string(rule.Target),
+			Modifier: rule.Modifier,
+			Operator: string(rule.Operator),
+			Expected: expected,
+			Actual:   actual,
+			Matched:  matched,
+		})
+	}
+
+	return diffs, nil
+}
+
+// toHTTPRequest rebuilds the HTTP request from the journal entry, so it can be used by the matchers.
+// Like the served request, the URL path has no path prefix and the request URI is the full one.
+func toHTTPRequest(ctx context.Context, entry *journal.Entry) (*http.Request, error) {
+	u, err := url.ParseRequestURI(entry.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse journal URL")
+	}
+	if entry.Path != "" {
+		u.Path, u.RawPath = entry.Path, ""
+	}
+
+	req, err := http.NewRequestWithContext(ctx, entry.Method, u.String(), bytes.NewBufferString(entry.Body))
+	if err != nil {
+		return nil, errors.Wrap(err, "build request from journal")
+	}
+	req.RequestURI = entry.URL
+	req.Header = entry.Headers.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Host = entry.Host
+	req.RemoteAddr = entry.RemoteAddr
+	if entry.Scheme == "https" {
+		req.TLS = &tls.ConnectionState{}
+	}
+	if major, minor, ok := http.ParseHTTPVersion(entry.Proto); ok {
+		req.Proto, req.ProtoMajor, req.ProtoMinor = entry.Proto, major, minor
+	}
+
+	return req, nil
+}
@@ -0,0 +1,199 @@
+package verification_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/journal"
+	"github.com/mockingio/mockingio/engine/mock"
+	. "github.com/mockingio/mockingio/engine/verification"
+)
+
+func TestVerify(t *testing.T) {
+	db := memory.New()
+	ctx := context.Background()
+	for _, entry := range []*journal.Entry{
+		{MockID: "mock1", Method: "GET", URL: "/users/1?page=1", Path: "/users/1", Headers: http.Header{"Authorization": {"Bearer 1"}}},
+		{MockID: "mock1", Method: "GET", URL: "/users/2?page=2", Path: "/users/2", Headers: http.Header{"Authorization": {"Bearer 2"}}},
+		{MockID: "mock1", Method: "POST", URL: "/users", Path: "/users", Body: `{"name": "joe"}`},
+		{MockID: "mock1", Method: "GET", URL: "/health", Host: "api.local:8080", Path: "/health", Proto: "HTTP/1.1", Scheme: "https", RemoteAddr: "10.0.0.1:5000"},
+		{MockID: "mock1", Method: "GET", URL: "/api/orders/7?page=1", Path: "/orders/7"},
+	} {
+		require.NoError(t, db.AddRequest(ctx, entry))
+	}
+
+	tests := []struct {
+		name         string
+		verification Verification
+		passed       bool
+		count        int
+	}{
+		{"exactly", Verification{Method: "GET", Path: "/users/:id", Mode: Exactly, Count: 2}, true, 2},
+		{"exactly, failed", Verification{Method: "GET", Path: "/users/:id", Mode: Exactly, Count: 1}, false, 2},
+		{"at least", Verification{Method: "GET", Path: "/users/*", Mode: AtLeast, Count: 1}, true, 2},
+		{"at least, failed", Verification{Method: "POST", Path: "/users", Mode: AtLeast, Count: 2}, false, 1},
+		{"at most", Verification{Method: "POST", Path: "/users", Mode: AtMost, Count: 1}, true, 1},
+		{"never", Verification{Method: "DELETE", Path: "/users/:id", Mode: Never}, true, 0},
+		{"never, failed", Verification{Path: "/users", Mode: Never}, false, 1},
+		{
+			"with connection rules",
+			Verification{
+				Path: "/health",
+				Rules: []mock.Rule{
+					{Target: mock.RemoteIP, Operator: mock.CIDR, Value: "10.0.0.0/8"},
+					{Target: mock.Host, Operator: mock.Equal, Value: "api.local"},
+					{Target: mock.Scheme, Operator: mock.Equal, Value: "https"},
+					{Target: mock.Protocol, Operator: mock.Equal, Value: "HTTP/1.1"},
+				},
+				Mode:  Exactly,
+				Count: 1,
+			},
+			true,
+			1,
+		},
+		{
+			"with rules",
+			Verification{
+				Method: "GET",
+				Path:   "/users/:id",
+				Rules: []mock.Rule{
+					{Target: mock.Header, Modifier: "Authorization", Operator: mock.Equal, Value: "Bearer 2"},
+					{Target: mock.QueryString, Modifier: "page", Operator: mock.Regex, Value: "[0-9]+"},
+				},
+				Mode:  Exactly,
+				Count: 1,
+			},
+			true,
+			1,
+		},
+		{
+			"with path prefix",
+			Verification{
+				Method: "GET",
+				Path:   "/orders/:id",
+				Rules: []mock.Rule{
+					{Target: mock.Path, Operator: mock.Equal, Value: "/api/orders/7"},
+					{Target: mock.RouteParam, Modifier: "id", Operator: mock.Equal, Value: "7"},
+					{Target: mock.QueryString, Modifier: "page", Operator: mock.Equal, Value: "1"},
+				},
+				Mode:  Exactly,
+				Count: 1,
+			},
+			true,
+			1,
+		},
+		{
+			"with body rule",
+			Verification{
+				Method: "POST",
+				Path:   "/users",
+				Rules:  []mock.Rule{{Target: mock.Body, Modifier: ".name", Operator: mock.Equal, Value: "joe"}},
+				Mode:   Exactly,
+				Count:  1,
+			},
+			true,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Verify(ctx, db, "mock1", tt.verification)
+			require.NoError(t, err)
+			assert.Equal(t, tt.passed, result.Passed, result.String())
+			assert.Equal(t, tt.count, result.Count)
+		})
+	}
+}
+
+func TestVerify_SchemaFile(t *testing.T) {
+	dir := t.TempDir()
+	schema := `{"type": "object", "required": ["name"]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user.json"), []byte(schema), 0644))
+
+	db := memory.New()
+	ctx := context.Background()
+	require.NoError(t, db.SetMock(ctx, &mock.Mock{ID: "mock1", FilePath: filepath.Join(dir, "mock.yml")}))
+	require.NoError(t, db.AddRequest(ctx, &journal.Entry{MockID: "mock1", Method: "POST", URL: "/users", Path: "/users", Body: `{"name": "joe"}`}))
+
+	// the schema file is relative to the mock file, not to the working directory
+	result, err := Verify(ctx, db, "mock1", Verification{
+		Path:  "/users",
+		Rules: []mock.Rule{{Target: mock.Body, Operator: mock.JSONSchema, Value: "user.json"}},
+		Mode:  Exactly,
+		Count: 1,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Passed, result.String())
+}
+
+func TestVerify_Closest(t *testing.T) {
+	db := memory.New()
+	ctx := context.Background()
+	_ = db.AddRequest(ctx, &journal.Entry{MockID: "mock1", Method: "POST", URL: "/orders", Path: "/orders"})
+	_ = db.AddRequest(ctx, &journal.Entry{MockID: "mock1", Method: "GET", URL: "/users/1", Path: "/users/1", Headers: http.Header{"X-Id": {"1"}}})
+
+	result, err := Verify(ctx, db, "mock1", Verification{
+		Method: "GET",
+		Path:   "/users/:id",
+		Rules:  []mock.Rule{{Target: mock.Header, Modifier: "X-Id", Operator: mock.Equal, Value: "2"}},
+		Mode:   AtLeast,
+		Count:  1,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.Passed)
+	require.Len(t, result.Closest, 2)
+	assert.Equal(t, "/users/1", result.Closest[0].Request.Path)
+	assert.Equal(t, []Diff{
+		{Target: "method", Operator: "equal", Expected: "GET", Actual: "GET", Matched: true},
+		{Target: "path", Operator: "equal", Expected: "/users/:id", Actual: "/users/1", Matched: true},
+		{Target: "header", Modifier: "X-Id", Operator: "equal", Expected: "2", Actual: "1", Matched: false},
+	}, result.Closest[0].Diffs)
+	assert.Contains(t, result.String(), `header[X-Id] equal "2", actual "1"`)
+}
+
+func TestVerification_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		verification Verification
+		isValid      bool
+	}{
+		{"valid", Verification{Path: "/", Mode: Exactly, Count: 1}, true},
+		{"missing path", Verification{Mode: Exactly}, false},
+		{"invalid mode", Verification{Path: "/", Mode: "sometimes"}, false},
+		{"negative count", Verification{Path: "/", Mode: AtMost, Count: -1}, false},
+		{"invalid rule", Verification{Path: "/", Mode: Never, Rules: []mock.Rule{{Target: mock.Header}}}, false},
+		{"invalid rule value", Verification{Path: "/", Mode: Never, Rules: []mock.Rule{{Target: mock.Body, Operator: mock.GreaterThan, Value: "abc"}}}, false},
+		{
+			"request number rule",
+			Verification{Path: "/", Mode: Never, Rules: []mock.Rule{{Target: mock.RequestNumber, Operator: mock.Equal, Value: "1"}}},
+			false,
+		},
+		{
+			"client certificate rule",
+			Verification{Path: "/", Mode: Never, Rules: []mock.Rule{{Target: mock.ClientCertCN, Operator: mock.Equal, Value: "billing"}}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.verification.Validate()
+			assert.Equal(t, tt.isValid, err == nil, err)
+		})
+	}
+}
+
+func TestVerification_Expected(t *testing.T) {
+	assert.Equal(t, "exactly 1 time", Verification{Mode: Exactly, Count: 1}.Expected())
+	assert.Equal(t, "at least 2 times", Verification{Mode: AtLeast, Count: 2}.Expected())
+	assert.Equal(t, "never", Verification{Mode: Never}.Expected())
+}
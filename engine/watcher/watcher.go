@@ -0,0 +1,307 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mockingio/mockingio/engine/database"
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/server"
+)
+
+// debounce is the time waited after a change, editors usually write a file in several steps
+const debounce = 100 * time.Millisecond
+
+type mockServer interface {
+	RestartMockServer(ctx context.Context, mo *mock.Mock) (*server.MockServerState, error)
+}
+
+// Watcher reloads the mocks when their files, or the files they reference, change
+type Watcher struct {
+	db     database.MockReadWriter
+	server mockServer
+	fsw    *fsnotify.Watcher
+
+	mu sync.Mutex
+	// mocks are the mock IDs, by mock file
+	mocks map[string]string
+	// references are the mock files, by referenced file, true if a change restarts the mock server
+	references map[string]map[string]bool
+	// restarts are the mock files whose scheduled reload restarts the mock server
+	restarts map[string]bool
+	timers   map[string]*time.Timer
+}
+
+func New(db database.MockReadWriter, srv mockServer) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "create file watcher")
+	}
+
+	return &Watcher{
+		db:         db,
+		server:     srv,
+		fsw:        fsw,
+		mocks:      map[string]string{},
+		references: map[string]map[string]bool{},
+		restarts:   map[string]bool{},
+		timers:     map[string]*time.Timer{},
+	}, nil
+}
+
+// Add watches the file of the mock, and the files referenced by the mock
+func (w *Watcher) Add(filename string, mok *mock.Mock) error {
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return errors.Wrap(err, "get absolute path")
+	}
+
+	w.mu.Lock()
+	w.mocks[filename] = mok.ID
+	w.mu.Unlock()
+
+	if err := w.watch(filename); err != nil {
+		return err
+	}
+
+	return w.watchReferences(filename, mok)
+}
+
+// Start reloads the mocks on changes, until the context is done
+func (w *Watcher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-w.fsw.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					w.changed(ctx, filepath.Clean(event.Name))
+				}
+			case err, ok := <-w.fsw.Errors:
+				if !ok {
+					return
+				}
+				log.WithError(err).Error("watch files")
+			}
+		}
+	}()
+}
+
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// changed schedules the reload of the mocks of the changed file
+func (w *Watcher) changed(ctx context.Context, file string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var mockFiles []string
+	if _, ok := w.mocks[file]; ok {
+		mockFiles = append(mockFiles, file)
+	}
+	for mockFile, restart := range w.references[file] {
+		mockFiles = append(mockFiles, mockFile)
+		if restart {
+			// the proto descriptors are loaded when the gRPC server starts
+			w.restarts[mockFile] = true
+		}
+	}
+
+	for _, mockFile := range mockFiles {
+		mockFile := mockFile
+		if timer, ok := w.timers[mockFile]; ok {
+			timer.Stop()
+		}
+		w.timers[mockFile] = time.AfterFunc(debounce, func() {
+			w.mu.Lock()
+			restart := w.restarts[mockFile]
+			delete(w.restarts, mockFile)
+			w.mu.Unlock()
+
+			w.reload(ctx, mockFile, file == mockFile, restart)
+		})
+	}
+}
+
+// reload parses the mock file again and swaps the mock, invalid mocks are ignored.
+// The server of the mock is restarted if its port, path prefix, TLS or gRPC config changed,
+// or if restart is set.
+func (w *Watcher) reload(ctx context.Context, filename string, mockChanged, restart bool) {
+	w.mu.Lock()
+	mockID := w.mocks[filename]
+	w.mu.Unlock()
+
+	logger := log.WithField("file", filename)
+
+	current, err := w.db.GetMock(ctx, mockID)
+	if err != nil || current == nil {
+		logger.WithError(err).Error("get mock to reload")
+		return
+	}
+
+	reloaded, err := mock.FromFile(filename, mock.WithIDGeneration())
+	if err != nil {
+		logger.WithError(err).Error("reload mock, keeping the last valid mock")
+		return
+	}
+	reloaded.ID = mockID
+
+	// the file is written by the persist option when the mock is saved
+	if mockChanged && !restart && sameMock(current, reloaded) {
+		return
+	}
+
+	if err := w.db.SetMock(ctx, reloaded); err != nil {
+		logger.WithError(err).Error("save reloaded mock")
+		return
+	}
+
+	if err := w.watchReferences(filename, reloaded); err != nil {
+		logger.WithError(err).Error("watch referenced files")
+	}
+
+	if restart || current.Port != reloaded.Port || current.PathPrefix != reloaded.PathPrefix ||
+		!reflect.DeepEqual(current.TLS, reloaded.TLS) || grpcChanged(current.GRPC, reloaded.GRPC) {
+		if _, err := w.server.RestartMockServer(ctx, reloaded); err != nil {
+			logger.WithError(err).Error("restart mock server, keeping the last valid mock")
+			_ = w.db.SetMock(ctx, current)
+			if _, err := w.server.RestartMockServer(ctx, current); err != nil {
+				logger.WithError(err).Error("restart mock server with the last valid mock")
+			}
+			return
+		}
+	}
+
+	logger.Info("mock reloaded")
+}
+
+// watchReferences watches the files referenced by the mock, e.g. the response and proto files
+func (w *Watcher) watchReferences(filename string, mok *mock.Mock) error {
+	// references are the referenced files, true if a change restarts the mock server
+	references := map[string]bool{}
+	for _, route := range mok.Routes {
+		for _, response := range route.Responses {
+			if response.FilePath != "" {
+				references[mok.ResolvePath(response.FilePath)] = false
+			}
+		}
+	}
+	if mok.Validation != nil {
+		references[mok.ResolvePath(mok.Validation.OpenAPI)] = false
+	}
+	for _, file := range grpcFiles(mok) {
+		references[file] = true
+	}
+
+	for reference, restart := range references {
+		reference, err := filepath.Abs(reference)
+		if err != nil {
+			return errors.Wrap(err, "get absolute path")
+		}
+
+		w.mu.Lock()
+		if w.references[reference] == nil {
+			w.references[reference] = map[string]bool{}
+		}
+		w.references[reference][filename] = restart
+		w.mu.Unlock()
+
+		if err := w.watch(reference); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watch watches the directory of the file, so files replaced by editors are still watched
+func (w *Watcher) watch(file string) error {
+	if err := w.fsw.Add(filepath.Dir(file)); err != nil {
+		return errors.Wrapf(err, "watch %v", file)
+	}
+
+	return nil
+}
+
+// grpcFiles returns the proto files and the descriptor set of the gRPC config of the mock.
+// Proto files are looked up in the import paths, like the gRPC server does.
+func grpcFiles(mok *mock.Mock) []string {
+	if mok.GRPC == nil {
+		return nil
+	}
+
+	var files []string
+	if mok.GRPC.DescriptorSet != "" {
+		files = append(files, mok.ResolvePath(mok.GRPC.DescriptorSet))
+	}
+
+	importPaths := mok.GRPC.ImportPaths
+	if len(importPaths) == 0 {
+		importPaths = []string{"."}
+	}
+	for _, protoFile := range mok.GRPC.ProtoFiles {
+		file := filepath.Join(mok.ResolvePath(importPaths[0]), protoFile)
+		for _, importPath := range importPaths {
+			candidate := filepath.Join(mok.ResolvePath(importPath), protoFile)
+			if _, err := os.Stat(candidate); err == nil {
+				file = candidate
+				break
+			}
+		}
+		files = append(files, file)
+	}
+
+	return files
+}
+
+// grpcChanged returns true if the gRPC listener config changed, the responses are read on each call
+func grpcChanged(a, b *mock.GRPC) bool {
+	if a == nil || b == nil {
+		return a != b
+	}
+
+	return a.Port != b.Port || a.DescriptorSet != b.DescriptorSet ||
+		!reflect.DeepEqual(a.ProtoFiles, b.ProtoFiles) || !reflect.DeepEqual(a.ImportPaths, b.ImportPaths) ||
+		!sameMethods(a.Methods, b.Methods)
+}
+
+// sameMethods returns true if the methods have the same names, the names are checked when the server starts
+func sameMethods(a, b []*mock.GRPCMethod) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameMock(a, b *mock.Mock) bool {
+	aJSON, err := a.JSON()
+	if err != nil {
+		return false
+	}
+
+	bJSON, err := b.JSON()
+	if err != nil {
+		return false
+	}
+
+	return aJSON == bJSON
+}
@@ -0,0 +1,169 @@
+package watcher_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mockingio/mockingio/engine/database/memory"
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/server"
+	. "github.com/mockingio/mockingio/engine/watcher"
+)
+
+type fakeServer struct {
+	mu       sync.Mutex
+	restarts []string
+}
+
+func (s *fakeServer) RestartMockServer(_ context.Context, mo *mock.Mock) (*server.MockServerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restarts = append(s.restarts, mo.Port)
+	return &server.MockServerState{}, nil
+}
+
+func (s *fakeServer) restarted() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.restarts...)
+}
+
+const mockYAML = `
+port: "%s"
+routes:
+  - method: GET
+    path: /hello
+    responses:
+      - status: 200
+        body: %s
+  - method: GET
+    path: /file
+    responses:
+      - status: 200
+        file_path: body.txt
+`
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "mock.yml")
+	writeMock(t, filename, "8080", "hello")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "body.txt"), []byte("file"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mok, err := mock.FromFile(filename, mock.WithIDGeneration())
+	require.NoError(t, err)
+	db := memory.New()
+	require.NoError(t, db.SetMock(ctx, mok))
+
+	srv := &fakeServer{}
+	w, err := New(db, srv)
+	require.NoError(t, err)
+	defer func() { _ = w.Close() }()
+	require.NoError(t, w.Add(filename, mok))
+	w.Start(ctx)
+
+	current := func() *mock.Mock {
+		m, _ := db.GetMock(ctx, mok.ID)
+		return m
+	}
+
+	t.Run("swaps the mock", func(t *testing.T) {
+		writeMock(t, filename, "8080", "world")
+		require.Eventually(t, func() bool {
+			return current().Routes[0].Responses[0].Body == "world"
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, mok.ID, current().ID)
+		assert.Empty(t, srv.restarted())
+	})
+
+	t.Run("keeps the last valid mock", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filename, []byte("routes: [invalid"), 0644))
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, "world", current().Routes[0].Responses[0].Body)
+	})
+
+	t.Run("restarts the server when the port changes", func(t *testing.T) {
+		writeMock(t, filename, "8081", "world")
+		require.Eventually(t, func() bool {
+			return len(srv.restarted()) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"8081"}, srv.restarted())
+		assert.Equal(t, "8081", current().Port)
+	})
+
+	t.Run("reloads when a referenced file changes", func(t *testing.T) {
+		before := current()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "body.txt"), []byte("changed"), 0644))
+		require.Eventually(t, func() bool {
+			return current() != before
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+const grpcMockYAML = `
+port: "8080"
+grpc:
+  port: "%s"
+  proto_files:
+    - hello.proto
+  methods:
+    - name: helloworld.Greeter/SayHello
+      responses:
+        - body: '{"message": "hello"}'
+`
+
+func TestWatcher_GRPC(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "mock.yml")
+	protoFile := filepath.Join(dir, "hello.proto")
+	require.NoError(t, os.WriteFile(filename, []byte(fmt.Sprintf(grpcMockYAML, "9090")), 0644))
+	require.NoError(t, os.WriteFile(protoFile, []byte(`syntax = "proto3";`), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mok, err := mock.FromFile(filename, mock.WithIDGeneration())
+	require.NoError(t, err)
+	db := memory.New()
+	require.NoError(t, db.SetMock(ctx, mok))
+
+	srv := &fakeServer{}
+	w, err := New(db, srv)
+	require.NoError(t, err)
+	defer func() { _ = w.Close() }()
+	require.NoError(t, w.Add(filename, mok))
+	w.Start(ctx)
+
+	t.Run("restarts the server when a proto file changes", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(protoFile, []byte(`syntax = "proto3"; package helloworld;`), 0644))
+		require.Eventually(t, func() bool {
+			return len(srv.restarted()) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("restarts the server when the gRPC port changes", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filename, []byte(fmt.Sprintf(grpcMockYAML, "9091")), 0644))
+		require.Eventually(t, func() bool {
+			return len(srv.restarted()) == 2
+		}, time.Second, 10*time.Millisecond)
+
+		m, err := db.GetMock(ctx, mok.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "9091", m.GRPC.Port)
+	})
+}
+
+func writeMock(t *testing.T, filename, port, body string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filename, []byte(fmt.Sprintf(mockYAML, port, body)), 0644))
+}
@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mockingio/mockingio/engine/matcher"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// closeTimeout is the time allowed to write the close frame
+const closeTimeout = time.Second
+
+var upgrader = websocket.Upgrader{
+	// mocks accept connections from any origin, like the CORS handling
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// webSocketRoute returns the WebSocket route matching the upgrade request, nil if there is none
+func webSocketRoute(mok *mock.Mock, r *http.Request) *mock.Route {
+	if !websocket.IsWebSocketUpgrade(r) {
+		return nil
+	}
+
+	for _, route := range mok.Routes {
+		if route.WebSocket != nil && !route.Disabled && matcher.MatchPath(route.Path, r.URL.Path) {
+			return route
+		}
+	}
+
+	return nil
+}
+
+// serveWebSocket upgrades the connection and serves the route messages until the connection is closed
+func (eng *Engine) serveWebSocket(w http.ResponseWriter, mok *mock.Mock, req matcher.Context, route *mock.Route) {
+	conn, err := upgrader.Upgrade(w, req.HTTPRequest, nil)
+	if err != nil {
+		// the upgrader already replied with an error status
+		log.WithError(err).Debug("upgrade websocket connection")
+		return
+	}
+	if rw, ok := w.(*responseWriter); ok {
+		rw.status = http.StatusSwitchingProtocols
+	}
+
+	ctx, cancel := context.WithCancel(req.HTTPRequest.Context())
+	defer cancel()
+
+	session := &webSocketSession{
+		eng:   eng,
+		conn:  conn,
+		mock:  mok,
+		route: route,
+		req:   req,
+	}
+	defer session.closeConn()
+
+	// the connection is hijacked, it is closed when the engine stops, which stops the read loop
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-eng.closed:
+			cancel()
+		}
+		session.closeConn()
+	}()
+
+	ws := route.WebSocket
+	if !session.send(ctx, req, ws.OnConnect) {
+		return
+	}
+
+	for _, push := range ws.Pushes {
+		go session.push(ctx, push)
+	}
+
+	if ws.Close != nil {
+		go func() {
+			if sleep(ctx, time.Duration(ws.Close.Delay)*time.Millisecond) {
+				session.close(*ws.Close)
+			}
+		}()
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if !session.reply(ctx, string(data)) {
+			return
+		}
+	}
+}
+
+type webSocketSession struct {
+	mu    sync.Mutex
+	eng   *Engine
+	conn  *websocket.Conn
+	mock  *mock.Mock
+	route *mock.Route
+	req   matcher.Context
+}
+
+// reply sends the messages of the first reply matching the message, it returns false once the connection is closed
+func (s *webSocketSession) reply(ctx context.Context, message string) bool {
+	req := s.req
+	req.Message = message
+
+	for _, reply := range s.route.WebSocket.Replies {
+		matched, err := matcher.MatchRules(s.mock, s.route, reply.RuleAggregation, reply.Rules, req, nil)
+		if err != nil {
+			log.WithError(err).Debug("matching websocket message")
+			continue
+		}
+		if !matched {
+			continue
+		}
+
+		if !s.send(ctx, req, reply.Messages) {
+			return false
+		}
+
+		if reply.Close != nil {
+			if sleep(ctx, time.Duration(reply.Close.Delay)*time.Millisecond) {
+				s.close(*reply.Close)
+			}
+			return false
+		}
+
+		return true
+	}
+
+	return true
+}
+
+func (s *webSocketSession) send(ctx context.Context, req matcher.Context, messages []mock.WebSocketMessage) bool {
+	for _, message := range messages {
+		if !sleep(ctx, time.Duration(message.Delay)*time.Millisecond) {
+			return false
+		}
+
+		if err := s.write(s.eng.expand(req, s.route, message.Data, s.route.WebSocket.Template)); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *webSocketSession) push(ctx context.Context, push mock.Push) {
+	if !sleep(ctx, time.Duration(push.Delay)*time.Millisecond) {
+		return
+	}
+
+	for {
+		if err := s.write(s.eng.expand(s.req, s.route, push.Data, s.route.WebSocket.Template)); err != nil {
+			return
+		}
+
+		if push.Interval == 0 || !sleep(ctx, time.Duration(push.Interval)*time.Millisecond) {
+			return
+		}
+	}
+}
+
+func (s *webSocketSession) write(data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conn.WriteMessage(websocket.TextMessage, []byte(data))
+}
+
+// close sends the close frame and closes the connection, which stops the read loop
+func (s *webSocketSession) close(c mock.Close) {
+	s.mu.Lock()
+	message := websocket.FormatCloseMessage(c.StatusCode(), c.Reason)
+	if err := s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(closeTimeout)); err != nil {
+		log.WithError(err).Debug("write websocket close message")
+	}
+	s.mu.Unlock()
+
+	s.closeConn()
+}
+
+func (s *webSocketSession) closeConn() {
+	_ = s.conn.Close()
+}
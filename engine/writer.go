@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// maxJournalBody is the size of the request and response bodies kept for the request journal,
+// the journal keeps the last requests of each mock in memory
+const maxJournalBody = 64 << 10
+
+// responseWriter keeps track of the status code and the body written to the client
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if remaining := maxJournalBody - w.body.Len(); remaining > 0 {
+		w.body.Write(truncate(data, remaining))
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// truncate returns the first size bytes of the data
+func truncate(data []byte, size int) []byte {
+	if len(data) > size {
+		return data[:size]
+	}
+	return data
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
@@ -20,11 +20,59 @@ const (
 	Cookie        = "cookie"
 	RouteParam    = "route_param"
 	RequestNumber = "request_number"
+	Message       = "message"
+
+	GraphQLOperationName = "graphql_operation_name"
+	GraphQLQuery         = "graphql_query"
+	GraphQLVariable      = "graphql_variable"
+
+	FormField            = "form_field"
+	MultipartContent     = "multipart_content"
+	MultipartFilename    = "multipart_filename"
+	MultipartContentType = "multipart_content_type"
+	XPath                = "xpath"
+
+	// RequestMethod is the method target, Method is the route builder
+	RequestMethod = "method"
+	Path          = "path"
+	RawQuery      = "raw_query"
+	Host          = "host"
+	Scheme        = "scheme"
+	RemoteIP      = "remote_ip"
+	Protocol      = "protocol"
+	ContentLength = "content_length"
 )
 
 const (
-	Equal = "equal"
-	Regex = "regex"
+	Equal           = "equal"
+	NotEqual        = "not_equal"
+	EqualIgnoreCase = "equal_ignore_case"
+	Regex           = "regex"
+	Contains        = "contains"
+	StartsWith      = "starts_with"
+	EndsWith        = "ends_with"
+	In              = "in"
+	Exists          = "exists"
+	Absent          = "absent"
+
+	GreaterThan        = "gt"
+	GreaterThanOrEqual = "gte"
+	LessThan           = "lt"
+	LessThanOrEqual    = "lte"
+	Between            = "between"
+
+	JSONContains = "json_contains"
+	JSONSchema   = "json_schema"
+	CIDR         = "cidr"
+)
+
+const (
+	ConnectionClose = "connection_close"
+	ConnectionReset = "connection_reset"
+	TruncatedBody   = "truncated_body"
+	MalformedBody   = "malformed_body"
+	ErrorStatus     = "error_status"
+	Stall           = "stall"
 )
 
 type Headers map[string]string
@@ -39,6 +87,7 @@ type Builder struct {
 	response *mock.Response
 	route    *mock.Route
 	config   *mock.Mock
+	db       *memory.Memory
 }
 
 // Start starts the mock server
@@ -53,6 +102,7 @@ func (b *Builder) Start() (*httptest.Server, error) {
 	mem := memory.New()
 	_ = mem.SetMock(context.Background(), b.config)
 	_ = mem.SetActiveSession(context.Background(), id, "session-id")
+	b.db = mem
 
 	m := engine.New(id, mem)
 
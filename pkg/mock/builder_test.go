@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -160,6 +161,152 @@ func TestBuilder_MatchedRoute(t *testing.T) {
 	})
 }
 
+func TestBuilder_Scenario(t *testing.T) {
+	builder := New()
+	builder.Post("/login").
+		Response(http.StatusOK, "logged in").
+		Scenario("auth", "", "logged_in")
+	builder.Get("/profile").
+		Response(http.StatusOK, "profile").
+		Scenario("auth", "logged_in", "")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assertNoMatchHTTPGETRequest(t, url(srv, "/profile"), 200)
+	assertHTTPPOSTRequest(t, url(srv, "/login"), "", 200, "logged in")
+	assertHTTPGETRequest(t, url(srv, "/profile"), 200, "profile")
+}
+
+func TestBuilder_Fault(t *testing.T) {
+	t.Run("error status", func(t *testing.T) {
+		srv, err := New().
+			Get("/hello").
+			Response(http.StatusOK, "world").
+			RandomErrorStatus(1, http.StatusServiceUnavailable).
+			Start()
+		require.NoError(t, err)
+		defer srv.Close()
+
+		assertHTTPGETRequest(t, url(srv, "/hello"), http.StatusServiceUnavailable, "Service Unavailable")
+	})
+
+	t.Run("connection close", func(t *testing.T) {
+		srv, err := New().
+			Get("/hello").
+			Response(http.StatusOK, "world").
+			Fault(ConnectionClose, 1).
+			Start()
+		require.NoError(t, err)
+		defer srv.Close()
+
+		_, err = http.Get(url(srv, "/hello"))
+		assert.Error(t, err)
+	})
+
+	t.Run("disabled fault", func(t *testing.T) {
+		srv, err := New().
+			Get("/hello").
+			Response(http.StatusOK, "world").
+			Fault(ConnectionClose, 0).
+			Start()
+		require.NoError(t, err)
+		defer srv.Close()
+
+		assertHTTPGETRequest(t, url(srv, "/hello"), http.StatusOK, "world")
+	})
+
+	t.Run("invalid fault", func(t *testing.T) {
+		_, err := New().
+			Get("/hello").
+			Response(http.StatusOK, "world").
+			Fault("explode", 1).
+			Start()
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_Throttle(t *testing.T) {
+	srv, err := New().
+		Get("/hello").
+		Response(http.StatusOK, "hello world").
+		Throttle(50, 0).
+		Chunked(5, 50).
+		Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	start := time.Now()
+	assertHTTPGETRequest(t, url(srv, "/hello"), http.StatusOK, "hello world")
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestBuilder_SSE(t *testing.T) {
+	srv, err := New().
+		Get("/events").
+		Response(http.StatusOK, "").
+		Template().
+		Event("greeting", "hello", 0).
+		Event("", `{"path": "{{ .Path }}"}`, 10).
+		RepeatEvents(1).
+		Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	event := "event: greeting\ndata: hello\n\ndata: {\"path\": \"/events\"}\n\n"
+	assertHTTPGETRequest(t, url(srv, "/events"), http.StatusOK, event+event)
+}
+
+func TestBuilder_WebSocket(t *testing.T) {
+	srv, err := New().
+		WebSocket("/ws").
+		OnConnect("hello", 0).
+		ReplyWhenPathInMessageEq(".type", "ping", "pong").
+		Close(4000, "done", 200).
+		Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "ping"}`)))
+	_, data, err = conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(data))
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, 4000), err)
+}
+
+func TestBuilder_GraphQL(t *testing.T) {
+	builder := New()
+	builder.Post("/graphql").
+		Response(http.StatusOK, `{"data": {"user": {"name": "joe"}}}`).
+		WhenGraphQLOperationEq("GetUser").
+		And(GraphQLVariable, ".id", Equal, "1")
+	builder.Post("/graphql").
+		Response(http.StatusOK, `{"data": {"createUser": {"id": "2"}}}`).
+		WhenGraphQLOperationEq("CreateUser")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assertHTTPPOSTRequest(t, url(srv, "/graphql"),
+		`{"query": "query GetUser($id: ID!) { user(id: $id) { name } }", "variables": {"id": "1"}}`,
+		http.StatusOK, `{"data": {"user": {"name": "joe"}}}`)
+	assertHTTPPOSTRequest(t, url(srv, "/graphql"),
+		`{"query": "mutation CreateUser { createUser { id } }"}`,
+		http.StatusOK, `{"data": {"createUser": {"id": "2"}}}`)
+}
+
 func TestBuilder_NoMatchedRoute(t *testing.T) {
 	t.Run("simple get", func(t *testing.T) {
 		srv, err := New().
@@ -346,3 +493,217 @@ func assertHTTPDELETETRequest(t *testing.T, url, payload string, expectedStatusC
 
 	assert.Equal(t, expectedResponseBody, string(body))
 }
+
+func TestBuilder_Verify(t *testing.T) {
+	builder := New()
+	builder.Get("/users/:id").
+		Response(http.StatusOK, "user")
+	builder.Post("/users").
+		Response(http.StatusCreated, "created")
+
+	assert.Error(t, builder.Called("GET", "/users/:id").Never())
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assertHTTPGETRequest(t, url(srv, "/users/1"), 200, "user")
+	assertHTTPGETRequest(t, url(srv, "/users/2"), 200, "user")
+
+	assert.NoError(t, builder.Called("GET", "/users/:id").Times(2))
+	assert.NoError(t, builder.Called("GET", "/users/:id").AtLeast(1))
+	assert.NoError(t, builder.Called("GET", "/users/:id").AtMost(2))
+	assert.NoError(t, builder.Called("GET", "/users/:id").With(Cookie, "name", Equal, "Jack").Times(2))
+	assert.NoError(t, builder.Called("POST", "/users").Never())
+
+	err = builder.Called("GET", "/users/:id").With(Header, "x-type", Equal, "x-women").AtLeast(1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `header[x-type] equal "x-women", actual "x-men"`)
+}
+
+func TestBuilder_Operators(t *testing.T) {
+	builder := New()
+	builder.Get("/users").
+		Response(http.StatusUnauthorized, "unauthorized").
+		WhenHeaderAbsent("Authorization")
+	builder.Get("/users").
+		Response(http.StatusOK, "admins").
+		WhenHeaderContains("Authorization", "admin").
+		And(QueryString, "role", In, "admin,owner")
+	builder.Get("/users").
+		Response(http.StatusOK, "users").
+		WhenHeaderExists("Authorization")
+	builder.Post("/users").
+		Response(http.StatusCreated, "adult").
+		WhenPathInBodyBetween(".age", 18, 65.5)
+	builder.Post("/users").
+		Response(http.StatusBadRequest, "invalid age").
+		WhenBodyContains("age")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assertHTTPGETRequest(t, url(srv, "/users"), http.StatusUnauthorized, "unauthorized")
+
+	req, _ := http.NewRequest(http.MethodGet, url(srv, "/users?role=owner"), nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, "admins", string(body))
+
+	req, _ = http.NewRequest(http.MethodGet, url(srv, "/users?role=guest"), nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, _ = io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, "users", string(body))
+
+	assertHTTPPOSTRequest(t, url(srv, "/users"), `{"age": 30}`, http.StatusCreated, "adult")
+	assertHTTPPOSTRequest(t, url(srv, "/users"), `{"age": 12}`, http.StatusBadRequest, "invalid age")
+}
+
+func TestBuilder_RuleGroups(t *testing.T) {
+	builder := New()
+	builder.Get("/items").
+		Response(http.StatusOK, "vip").
+		WhenGroup(Any(
+			All(Rule(QueryString, "tier", Equal, "gold"), Rule(QueryString, "region", Equal, "eu")),
+			Rule(QueryString, "vip", Equal, "true"),
+		))
+	builder.Get("/items").
+		Response(http.StatusOK, "allowed").
+		WhenGroup(None(Rule(QueryString, "banned", Exists, "")))
+	builder.Get("/items").
+		Response(http.StatusForbidden, "banned")
+	builder.Get("/chain").
+		Response(http.StatusOK, "matched").
+		When(QueryString, "a", Equal, "1").
+		Or(QueryString, "b", Equal, "1").
+		AndGroup(Rule(QueryString, "c", Equal, "1"))
+	builder.Get("/chain").
+		Response(http.StatusOK, "not matched")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/items?tier=gold&region=eu", "vip"},
+		{"/items?vip=true&banned=1", "vip"},
+		{"/items?tier=gold", "allowed"},
+		{"/items?banned=1", "banned"},
+		{"/chain?b=1&c=1", "matched"},
+		{"/chain?a=1", "not matched"},
+		{"/chain?c=1", "not matched"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res, err := http.Get(url(srv, tt.path))
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			body, _ := io.ReadAll(res.Body)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestBuilder_JSONBody(t *testing.T) {
+	builder := New()
+	builder.Post("/users").
+		Response(http.StatusOK, "admin").
+		WhenBodyJSONContains(`{"roles": ["admin"]}`)
+	builder.Post("/users").
+		Response(http.StatusCreated, "created").
+		WhenBodyJSONSchema(`{"type": "object", "required": ["name"]}`)
+	builder.Post("/users").
+		Response(http.StatusBadRequest, "invalid")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assertHTTPPOSTRequest(t, url(srv, "/users"), `{"name": "joe", "roles": ["user", "admin"]}`, http.StatusOK, "admin")
+	assertHTTPPOSTRequest(t, url(srv, "/users"), `{"name": "joe", "roles": ["user"]}`, http.StatusCreated, "created")
+	assertHTTPPOSTRequest(t, url(srv, "/users"), `{"roles": ["user"]}`, http.StatusBadRequest, "invalid")
+}
+
+func TestBuilder_FormAndXMLBody(t *testing.T) {
+	builder := New()
+	builder.Post("/login").
+		Response(http.StatusOK, "welcome").
+		WhenFormFieldEq("user", "joe")
+	builder.Post("/soap").
+		Response(http.StatusOK, "<order>42</order>").
+		WhenXPathEq("//OrderID", "42")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	res, err := http.Post(url(srv, "/login"), "application/x-www-form-urlencoded", strings.NewReader("user=joe&password=secret"))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, "welcome", string(body))
+
+	res, err = http.Post(url(srv, "/soap"), "text/xml", strings.NewReader("<GetOrder><OrderID>42</OrderID></GetOrder>"))
+	require.NoError(t, err)
+	body, _ = io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, "<order>42</order>", string(body))
+}
+
+func TestBuilder_RequestTargets(t *testing.T) {
+	builder := New()
+	builder.Get("/*").
+		Response(http.StatusOK, "admin").
+		WhenPathStartsWith("/admin/").
+		And(RemoteIP, "", CIDR, "127.0.0.0/8,::1")
+	builder.Get("/*").
+		Response(http.StatusOK, "internal").
+		WhenHostEq("internal.example.com")
+	builder.Get("/*").
+		Response(http.StatusNotFound, "not found")
+
+	srv, err := builder.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assertHTTPGETRequest(t, url(srv, "/admin/users"), http.StatusOK, "admin")
+	assertHTTPGETRequest(t, url(srv, "/users"), http.StatusNotFound, "not found")
+
+	req, _ := http.NewRequest(http.MethodGet, url(srv, "/users"), nil)
+	req.Host = "internal.example.com:8080"
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, "internal", string(body))
+}
+
+func TestFromOpenAPI(t *testing.T) {
+	mok, err := FromOpenAPI([]byte(`
+openapi: 3.0.0
+info: {title: Users, version: "1"}
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: user}
+`))
+	require.NoError(t, err)
+	require.Len(t, mok.Routes, 1)
+	assert.Equal(t, "/users/:id", mok.Routes[0].Path)
+	assert.Equal(t, 200, mok.Routes[0].Responses[0].Status)
+}
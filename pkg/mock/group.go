@@ -0,0 +1,152 @@
+package mock
+
+import (
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// Group is a rule, or a group of rules built with All, Any and None.
+// e.g. Any(All(Rule(...), Rule(...)), None(Rule(...)))
+type Group struct {
+	group mock.RuleGroup
+}
+
+// Rule is a group of a single rule
+func Rule(target, modifier, operator, value string) Group {
+	return Group{group: mock.RuleGroup{Rule: newRule(target, modifier, operator, value)}}
+}
+
+// All is a group matching if all the groups match
+func All(groups ...Group) Group {
+	return Group{group: mock.RuleGroup{All: ruleGroups(groups)}}
+}
+
+// Any is a group matching if one of the groups matches
+func Any(groups ...Group) Group {
+	return Group{group: mock.RuleGroup{Any: ruleGroups(groups)}}
+}
+
+// None is a group matching if none of the groups matches
+func None(groups ...Group) Group {
+	return Group{group: mock.RuleGroup{None: ruleGroups(groups)}}
+}
+
+// WhenGroup is a response rule group. It can be used to match a request with nested rules.
+func (r *Response) WhenGroup(group Group) *When {
+	r.builder.addGroup(mock.And, group.group)
+
+	return &When{
+		builder: r.builder,
+	}
+}
+
+// AndGroup is used to combine the previous rules and a group with AND operator
+func (w *When) AndGroup(group Group) *And {
+	w.builder.addGroup(mock.And, group.group)
+
+	return &And{
+		builder: w.builder,
+	}
+}
+
+// OrGroup is used to combine the previous rules and a group with OR operator
+func (w *When) OrGroup(group Group) *Or {
+	w.builder.addGroup(mock.Or, group.group)
+
+	return &Or{
+		builder: w.builder,
+	}
+}
+
+// AndGroup is used to combine the previous rules and a group with AND operator
+func (a *And) AndGroup(group Group) *And {
+	a.builder.addGroup(mock.And, group.group)
+
+	return a
+}
+
+// OrGroup is used to combine the previous rules and a group with OR operator
+func (a *And) OrGroup(group Group) *Or {
+	a.builder.addGroup(mock.Or, group.group)
+
+	return &Or{
+		builder: a.builder,
+	}
+}
+
+// OrGroup is used to combine the previous rules and a group with OR operator
+func (o *Or) OrGroup(group Group) *Or {
+	o.builder.addGroup(mock.Or, group.group)
+
+	return o
+}
+
+// AndGroup is used to combine the previous rules and a group with AND operator
+func (o *Or) AndGroup(group Group) *And {
+	o.builder.addGroup(mock.And, group.group)
+
+	return &And{
+		builder: o.builder,
+	}
+}
+
+// addRule adds a rule to the response. Rules are flat, unless a group was added to the response.
+func (b *Builder) addRule(aggregation mock.RuleAggregation, rule mock.Rule) {
+	if b.response.Match != nil {
+		b.addGroup(aggregation, mock.RuleGroup{Rule: rule})
+		return
+	}
+
+	b.response.RuleAggregation = aggregation
+	b.response.Rules = append(b.response.Rules, rule)
+}
+
+// addGroup combines the rules of the response with the group, from left to right:
+// When(A).Or(B).AndGroup(C) is (A or B) and C
+func (b *Builder) addGroup(aggregation mock.RuleAggregation, group mock.RuleGroup) {
+	current := b.response.Match
+	if len(b.response.Rules) > 0 {
+		current = &mock.RuleGroup{}
+		for _, rule := range b.response.Rules {
+			if b.response.RuleAggregation == mock.Or {
+				current.Any = append(current.Any, mock.RuleGroup{Rule: rule})
+			} else {
+				current.All = append(current.All, mock.RuleGroup{Rule: rule})
+			}
+		}
+		b.response.Rules = nil
+		b.response.RuleAggregation = ""
+	}
+
+	switch {
+	case current == nil:
+		b.response.Match = &group
+	case aggregation == mock.Or && !current.IsRule() && len(current.All) == 0 && len(current.None) == 0:
+		current.Any = append(current.Any, group)
+		b.response.Match = current
+	case aggregation == mock.Or:
+		b.response.Match = &mock.RuleGroup{Any: []mock.RuleGroup{*current, group}}
+	case !current.IsRule() && len(current.Any) == 0 && len(current.None) == 0:
+		current.All = append(current.All, group)
+		b.response.Match = current
+	default:
+		b.response.Match = &mock.RuleGroup{All: []mock.RuleGroup{*current, group}}
+	}
+}
+
+func newRule(target, modifier, operator, value string) mock.Rule {
+	return mock.Rule{
+		Target:   mock.Target(target),
+		Modifier: modifier,
+		Operator: mock.Operator(operator),
+		Value:    value,
+	}
+}
+
+func ruleGroups(groups []Group) []mock.RuleGroup {
+	ruleGroups := make([]mock.RuleGroup, len(groups))
+	for i, group := range groups {
+		ruleGroups[i] = group.group
+	}
+
+	return ruleGroups
+}
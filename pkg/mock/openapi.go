@@ -0,0 +1,12 @@
+package mock
+
+import (
+	"github.com/mockingio/mockingio/engine/importer"
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+// FromOpenAPI builds a mock from an OpenAPI 3 spec in YAML or JSON, with a route per operation
+// and a response per documented status code
+func FromOpenAPI(data []byte) (*mock.Mock, error) {
+	return importer.FromOpenAPI(data)
+}
@@ -1,7 +1,9 @@
 package mock
 
 import (
+	"fmt"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/mockingio/mockingio/engine/mock"
 )
@@ -19,6 +21,90 @@ func (r *Response) Delay(min, max int) *Response {
 	return r
 }
 
+// Throttle caps the transfer rate of the body in bytes per second, after waiting for the time to first byte in milliseconds.
+func (r *Response) Throttle(timeToFirstByte, bytesPerSecond int) *Response {
+	r.builder.response.Throttle = &mock.Throttle{
+		TimeToFirstByte: timeToFirstByte,
+		BytesPerSecond:  bytesPerSecond,
+	}
+	return r
+}
+
+// Chunked writes the body in chunks of the given size in bytes, waiting for the delay in milliseconds between chunks.
+func (r *Response) Chunked(chunkSize, chunkDelay int) *Response {
+	if r.builder.response.Throttle == nil {
+		r.builder.response.Throttle = &mock.Throttle{}
+	}
+	r.builder.response.Throttle.ChunkSize = chunkSize
+	r.builder.response.Throttle.ChunkDelay = chunkDelay
+	return r
+}
+
+// Template renders the body, headers and events as Go templates with the request data, e.g. {{ param "id" }}
+func (r *Response) Template() *Response {
+	r.builder.response.Template = true
+	return r
+}
+
+// Event streams a server-sent event instead of the body, after waiting for the delay in milliseconds.
+// The data supports faker, and templates if enabled.
+func (r *Response) Event(event, data string, delay int) *Response {
+	r.sse().Events = append(r.sse().Events, mock.Event{
+		Event: event,
+		Data:  data,
+		Delay: delay,
+	})
+	return r
+}
+
+// RepeatEvents sends the events again the given number of times
+func (r *Response) RepeatEvents(times int) *Response {
+	r.sse().Repeat = times
+	return r
+}
+
+// LoopEvents sends the events again until the client disconnects, one of the events must have a delay
+func (r *Response) LoopEvents() *Response {
+	r.sse().Loop = true
+	return r
+}
+
+func (r *Response) sse() *mock.SSE {
+	if r.builder.response.SSE == nil {
+		r.builder.response.SSE = &mock.SSE{}
+	}
+	return r.builder.response.SSE
+}
+
+// Fault breaks the response on purpose, with the given probability between 0 and 1.
+// A probability of 1 means the fault always happens, 0 that it never happens.
+func (r *Response) Fault(faultType string, probability float64) *Response {
+	r.builder.response.Fault = &mock.Fault{
+		Type:        mock.FaultType(faultType),
+		Probability: &probability,
+	}
+	return r
+}
+
+// RandomErrorStatus responds with one of the statuses, 500 if empty, with the given probability between 0 and 1.
+func (r *Response) RandomErrorStatus(probability float64, statuses ...int) *Response {
+	r.builder.response.Fault = &mock.Fault{
+		Type:        mock.FaultErrorStatus,
+		Probability: &probability,
+		Statuses:    statuses,
+	}
+	return r
+}
+
+// Scenario makes the response part of a scenario. The response is only matched when the scenario
+// is in the required state, and moves the scenario to the new state when served. Empty states are ignored.
+func (r *Response) Scenario(name, requiredState, newState string) *Response {
+	r.builder.response.Scenario = name
+	r.builder.response.RequiredState = requiredState
+	r.builder.response.NewState = newState
+	return r
+}
+
 // Start starts the mock server
 func (r *Response) Start() (*httptest.Server, error) {
 	return r.builder.Start()
@@ -27,13 +113,7 @@ func (r *Response) Start() (*httptest.Server, error) {
 // When is a response rule.
 // It can be used to match a request.
 func (r *Response) When(target, modifier, operator, value string) *When {
-	r.builder.response.RuleAggregation = mock.And
-	r.builder.response.Rules = append(r.builder.response.Rules, mock.Rule{
-		Target:   mock.Target(target),
-		Modifier: modifier,
-		Operator: mock.Operator(operator),
-		Value:    value,
-	})
+	r.builder.addRule(mock.And, newRule(target, modifier, operator, value))
 
 	return &When{
 		builder: r.builder,
@@ -65,6 +145,86 @@ func (r *Response) WhenRouteParamEq(routeParamName, routeParamValue string) *Whe
 	return r.When(Header, routeParamName, Equal, routeParamValue)
 }
 
+// WhenGraphQLOperationEq is a response rule. It can be used to match a GraphQL operation name with the given value.
+func (r *Response) WhenGraphQLOperationEq(operationName string) *When {
+	return r.When(GraphQLOperationName, "", Equal, operationName)
+}
+
+// WhenGraphQLVariableEq is a response rule. It can be used to match a GraphQL variable with the given value.
+func (r *Response) WhenGraphQLVariableEq(field string, value string) *When {
+	return r.When(GraphQLVariable, field, Equal, value)
+}
+
+// WhenBodyContains is a response rule. It can be used to match a request body containing the given value.
+func (r *Response) WhenBodyContains(value string) *When {
+	return r.When(Body, "", Contains, value)
+}
+
+// WhenHeaderContains is a response rule. It can be used to match a request header containing the given value.
+func (r *Response) WhenHeaderContains(headerName, value string) *When {
+	return r.When(Header, headerName, Contains, value)
+}
+
+// WhenHeaderExists is a response rule. It can be used to match a request with the given header.
+func (r *Response) WhenHeaderExists(headerName string) *When {
+	return r.When(Header, headerName, Exists, "")
+}
+
+// WhenHeaderAbsent is a response rule. It can be used to match a request without the given header.
+func (r *Response) WhenHeaderAbsent(headerName string) *When {
+	return r.When(Header, headerName, Absent, "")
+}
+
+// WhenQueryStringIn is a response rule. It can be used to match a request query string with one of the given values.
+func (r *Response) WhenQueryStringIn(queryStringName string, values ...string) *When {
+	return r.When(QueryString, queryStringName, In, strings.Join(values, ","))
+}
+
+// WhenPathInBodyBetween is a response rule. It can be used to match a child body with a number between min and max, inclusive.
+func (r *Response) WhenPathInBodyBetween(field string, min, max float64) *When {
+	return r.When(Body, field, Between, fmt.Sprintf("%v,%v", min, max))
+}
+
+// WhenFormFieldEq is a response rule. It can be used to match a form field with the given value.
+func (r *Response) WhenFormFieldEq(fieldName, value string) *When {
+	return r.When(FormField, fieldName, Equal, value)
+}
+
+// WhenMultipartFilenameEq is a response rule. It can be used to match the filename of a multipart part with the given value.
+func (r *Response) WhenMultipartFilenameEq(partName, filename string) *When {
+	return r.When(MultipartFilename, partName, Equal, filename)
+}
+
+// WhenXPathEq is a response rule. It can be used to match the result of an XPath expression over an XML body with the given value.
+func (r *Response) WhenXPathEq(expression, value string) *When {
+	return r.When(XPath, expression, Equal, value)
+}
+
+// WhenPathStartsWith is a response rule. It can be used to match a request path starting with the given prefix.
+func (r *Response) WhenPathStartsWith(prefix string) *When {
+	return r.When(Path, "", StartsWith, prefix)
+}
+
+// WhenHostEq is a response rule. It can be used to match a request host, without port, with the given value.
+func (r *Response) WhenHostEq(host string) *When {
+	return r.When(Host, "", Equal, host)
+}
+
+// WhenRemoteIPIn is a response rule. It can be used to match a client IP in one of the given CIDR ranges or IPs.
+func (r *Response) WhenRemoteIPIn(cidrs ...string) *When {
+	return r.When(RemoteIP, "", CIDR, strings.Join(cidrs, ","))
+}
+
+// WhenBodyJSONContains is a response rule. It can be used to match a JSON request body containing the given JSON.
+func (r *Response) WhenBodyJSONContains(value string) *When {
+	return r.When(Body, "", JSONContains, value)
+}
+
+// WhenBodyJSONSchema is a response rule. It can be used to match a JSON request body valid against the given inline schema.
+func (r *Response) WhenBodyJSONSchema(schema string) *When {
+	return r.When(Body, "", JSONSchema, schema)
+}
+
 type And struct {
 	builder *Builder
 }
@@ -76,12 +236,7 @@ func (a *And) Start() (*httptest.Server, error) {
 
 // And is used to combine multiple rules with AND operator
 func (a *And) And(target, modifier, operator, value string) *And {
-	a.builder.response.Rules = append(a.builder.response.Rules, mock.Rule{
-		Target:   mock.Target(target),
-		Modifier: modifier,
-		Operator: mock.Operator(operator),
-		Value:    value,
-	})
+	a.builder.addRule(mock.And, newRule(target, modifier, operator, value))
 
 	return a
 }
@@ -92,12 +247,7 @@ type Or struct {
 
 // Or is used to combine multiple rules with OR operator
 func (o *Or) Or(target, modifier, operator, value string) *Or {
-	o.builder.response.Rules = append(o.builder.response.Rules, mock.Rule{
-		Target:   mock.Target(target),
-		Modifier: modifier,
-		Operator: mock.Operator(operator),
-		Value:    value,
-	})
+	o.builder.addRule(mock.Or, newRule(target, modifier, operator, value))
 
 	return o
 }
@@ -0,0 +1,77 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mockingio/mockingio/engine/mock"
+	"github.com/mockingio/mockingio/engine/verification"
+)
+
+type Verify struct {
+	builder      *Builder
+	verification verification.Verification
+}
+
+// Called verifies the requests received by the mock server for the given method and path
+func (b *Builder) Called(method, path string) *Verify {
+	return &Verify{
+		builder: b,
+		verification: verification.Verification{
+			Method: method,
+			Path:   path,
+		},
+	}
+}
+
+// With is a rule the verified requests must match
+func (v *Verify) With(target, modifier, operator, value string) *Verify {
+	v.verification.Rules = append(v.verification.Rules, mock.Rule{
+		Target:   mock.Target(target),
+		Modifier: modifier,
+		Operator: mock.Operator(operator),
+		Value:    value,
+	})
+	return v
+}
+
+// Times returns an error if the route was not called exactly n times
+func (v *Verify) Times(n int) error {
+	return v.verify(verification.Exactly, n)
+}
+
+// AtLeast returns an error if the route was called less than n times
+func (v *Verify) AtLeast(n int) error {
+	return v.verify(verification.AtLeast, n)
+}
+
+// AtMost returns an error if the route was called more than n times
+func (v *Verify) AtMost(n int) error {
+	return v.verify(verification.AtMost, n)
+}
+
+// Never returns an error if the route was called
+func (v *Verify) Never() error {
+	return v.verify(verification.Never, 0)
+}
+
+func (v *Verify) verify(mode verification.CountMode, count int) error {
+	if v.builder.db == nil {
+		return errors.New("mock server is not started")
+	}
+
+	v.verification.Mode = mode
+	v.verification.Count = count
+
+	result, err := verification.Verify(context.Background(), v.builder.db, v.builder.config.ID, v.verification)
+	if err != nil {
+		return err
+	}
+
+	if !result.Passed {
+		return fmt.Errorf("%s %s: %s", v.verification.Method, v.verification.Path, result)
+	}
+
+	return nil
+}
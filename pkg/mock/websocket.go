@@ -0,0 +1,80 @@
+package mock
+
+import (
+	"net/http/httptest"
+
+	"github.com/mockingio/mockingio/engine/mock"
+)
+
+type WebSocket struct {
+	builder *Builder
+}
+
+// WebSocket make the WebSocket route
+func (b *Builder) WebSocket(url string) *WebSocket {
+	b.clear()
+	b.route = &mock.Route{
+		Method:    "GET",
+		Path:      url,
+		WebSocket: &mock.WebSocket{},
+	}
+	return &WebSocket{
+		builder: b,
+	}
+}
+
+// Template renders the messages as Go templates with the request data and the received message
+func (w *WebSocket) Template() *WebSocket {
+	w.builder.route.WebSocket.Template = true
+	return w
+}
+
+// OnConnect sends the message when the client connects, after waiting for the delay in milliseconds
+func (w *WebSocket) OnConnect(data string, delay int) *WebSocket {
+	ws := w.builder.route.WebSocket
+	ws.OnConnect = append(ws.OnConnect, mock.WebSocketMessage{Data: data, Delay: delay})
+	return w
+}
+
+// ReplyWhen sends the message when a client message matches the rule
+func (w *WebSocket) ReplyWhen(target, modifier, operator, value, data string) *WebSocket {
+	ws := w.builder.route.WebSocket
+	ws.Replies = append(ws.Replies, mock.Reply{
+		Rules: []mock.Rule{{
+			Target:   mock.Target(target),
+			Modifier: modifier,
+			Operator: mock.Operator(operator),
+			Value:    value,
+		}},
+		Messages: []mock.WebSocketMessage{{Data: data}},
+	})
+	return w
+}
+
+// ReplyWhenMessageEq sends the message when a client message equals the value
+func (w *WebSocket) ReplyWhenMessageEq(value, data string) *WebSocket {
+	return w.ReplyWhen(Message, "", Equal, value, data)
+}
+
+// ReplyWhenPathInMessageEq sends the message when a child of a JSON client message equals the value
+func (w *WebSocket) ReplyWhenPathInMessageEq(field, value, data string) *WebSocket {
+	return w.ReplyWhen(Message, field, Equal, value, data)
+}
+
+// Push sends the message after the delay, then every interval if not 0, both in milliseconds
+func (w *WebSocket) Push(data string, delay, interval int) *WebSocket {
+	ws := w.builder.route.WebSocket
+	ws.Pushes = append(ws.Pushes, mock.Push{Data: data, Delay: delay, Interval: interval})
+	return w
+}
+
+// Close closes the connection with the code and reason, after the delay in milliseconds
+func (w *WebSocket) Close(code int, reason string, delay int) *WebSocket {
+	w.builder.route.WebSocket.Close = &mock.Close{Code: code, Reason: reason, Delay: delay}
+	return w
+}
+
+// Start starts the mock server
+func (w *WebSocket) Start() (*httptest.Server, error) {
+	return w.builder.Start()
+}
@@ -17,13 +17,7 @@ func (w *When) Start() (*httptest.Server, error) {
 
 // And is used to combine multiple rules with AND operator
 func (w *When) And(target, modifier, operator, value string) *And {
-	w.builder.response.RuleAggregation = mock.And
-	w.builder.response.Rules = append(w.builder.response.Rules, mock.Rule{
-		Target:   mock.Target(target),
-		Modifier: modifier,
-		Operator: mock.Operator(operator),
-		Value:    value,
-	})
+	w.builder.addRule(mock.And, newRule(target, modifier, operator, value))
 
 	return &And{
 		builder: w.builder,
@@ -32,13 +26,7 @@ func (w *When) And(target, modifier, operator, value string) *And {
 
 // Or is used to combine multiple rules with OR operator
 func (w *When) Or(target, modifier, operator, value string) *Or {
-	w.builder.response.RuleAggregation = mock.Or
-	w.builder.response.Rules = append(w.builder.response.Rules, mock.Rule{
-		Target:   mock.Target(target),
-		Modifier: modifier,
-		Operator: mock.Operator(operator),
-		Value:    value,
-	})
+	w.builder.addRule(mock.Or, newRule(target, modifier, operator, value))
 
 	return &Or{
 		builder: w.builder,